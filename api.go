@@ -4,27 +4,32 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
-	"time"
-
-	"github.com/TrixiS/goram/flood"
 )
 
-// Represents Telegram API error
+// Represents Telegram API error. See errors.go for known error kinds.
+//
+// Transport errors (network failures, context cancellation, etc.) are returned as is, not as *goram.APIError.
 type APIError struct {
 	Method      string // Called API method
 	Description string
 	ErrorCode   int
 	Parameters  *ResponseParameters
+	Err         error // Optional. Error wrapped by the API error, returned by Unwrap()
 }
 
 func (a *APIError) Error() string {
 	return fmt.Sprintf("%s: %d %s", a.Method, a.ErrorCode, a.Description)
 }
 
+func (a *APIError) Unwrap() error {
+	return a.Err
+}
+
 type apiResponse[R any] struct {
 	OK          bool                `json:"ok"`
 	Description string              `json:"description"`
@@ -46,209 +51,161 @@ type apiRequest interface {
 	writeMultipart(*multipart.Writer)
 }
 
-const maxRetries = 5
-
 func makeRequest[R any](
 	ctx context.Context,
-	client *http.Client,
-	baseURL string,
+	bot *Bot,
 	apiMethod string,
-	floodHandler flood.Handler,
 	data apiRequest,
 ) (*apiResponse[R], error) {
-	url := baseURL + apiMethod
-	body, contentType := prepareRequestBody(data)
-
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		response, retryRequired, err := doRequestAttempt[R](
-			ctx,
-			client,
-			url,
-			contentType,
-			body,
-			apiMethod,
-			floodHandler,
-			data,
-			attempt,
-		)
-
-		if err != nil || !retryRequired {
-			return response, err
-		}
+	result, err := bot.request(ctx, apiMethod, data, false)
 
-		if body != nil {
-			body.Seek(0, io.SeekStart)
-		}
+	if err != nil {
+		return nil, err
 	}
 
-	return nil, context.DeadlineExceeded
+	response := &apiResponse[R]{OK: true}
+
+	if err := json.Unmarshal(result, &response.Result); err != nil {
+		return nil, err
+	}
+
+	return response, nil
 }
 
 func makeVoidRequest(
 	ctx context.Context,
-	client *http.Client,
-	baseURL string,
+	bot *Bot,
 	apiMethod string,
-	floodHandler flood.Handler,
 	data apiRequest,
 ) error {
-	url := baseURL + apiMethod
-	body, contentType := prepareRequestBody(data)
-
-	for attempt := 0; attempt < maxRetries; attempt++ {
-		retryRequired, err := doVoidRequestAttempt(
-			ctx,
-			client,
-			url,
-			contentType,
-			body,
-			apiMethod,
-			floodHandler,
-			data,
-			attempt,
-		)
-
-		if err != nil || !retryRequired {
-			return err
-		}
+	_, err := bot.request(ctx, apiMethod, data, true)
+	return err
+}
+
+// Passes the request through BotOptions.Middlewares and BotOptions.FloodHandler to the transport.
+func (b *Bot) request(ctx context.Context, apiMethod string, data apiRequest, void bool) (json.RawMessage, error) {
+	next := b.transport(void)
 
-		body.Seek(0, io.SeekStart)
+	if b.Options.FloodHandler != nil {
+		next = wrapMiddleware(FloodMiddleware(b.Options.FloodHandler), next)
 	}
 
-	return context.DeadlineExceeded
+	for i := len(b.Options.Middlewares) - 1; i >= 0; i-- {
+		next = wrapMiddleware(b.Options.Middlewares[i], next)
+	}
+
+	return next(ctx, apiMethod, data)
 }
 
-func doRequestAttempt[R any](
-	ctx context.Context,
-	client *http.Client,
-	url string,
-	contentType string,
-	body io.ReadSeeker,
-	apiMethod string,
-	floodHandler flood.Handler,
-	data apiRequest,
-	attempt int,
-) (*apiResponse[R], bool, error) {
-	res, err := executeHTTPRequest(
-		ctx,
-		client,
-		url,
-		contentType,
-		body,
-		apiMethod,
-		floodHandler,
-		data,
+// Returns the last RequestFunc in the middleware chain, which actually sends the request.
+//
+// Multipart body is built once and reused if the same request is sent again (for example, by goram.FloodMiddleware),
+// because file readers can not be read twice.
+func (b *Bot) transport(void bool) RequestFunc {
+	var (
+		lastRequest apiRequest
+		body        io.ReadSeeker
+		contentType string
 	)
 
-	if err != nil {
-		return nil, false, err
-	}
-
-	defer res.Body.Close()
+	return func(ctx context.Context, apiMethod string, request any) (json.RawMessage, error) {
+		data, ok := request.(apiRequest)
 
-	response := &apiResponse[R]{}
+		if !ok && request != nil {
+			return nil, fmt.Errorf("unexpected request type %T", request)
+		}
 
-	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
-		return nil, false, err
-	}
+		if body == nil || data != lastRequest {
+			lastRequest = data
+			body, contentType = prepareRequestBody(data)
+		} else {
+			body.Seek(0, io.SeekStart)
+		}
 
-	if response.OK {
-		return response, false, nil
-	}
+		decode := decodeResponse
 
-	if response.ErrorCode != http.StatusTooManyRequests ||
-		response.Parameters == nil ||
-		floodHandler == nil ||
-		attempt == maxRetries-1 {
+		if void {
+			decode = decodeVoidResponse
+		}
 
-		return response, false, response.error(apiMethod)
+		return b.doRequest(ctx, apiMethod, body, contentType, decode)
 	}
-
-	duration := time.Second * time.Duration(response.Parameters.RetryAfter)
-	floodHandler.Handle(ctx, apiMethod, data, duration)
-
-	return nil, true, nil
 }
 
-func doVoidRequestAttempt(
-	ctx context.Context,
-	client *http.Client,
-	url string,
-	contentType string,
-	body io.ReadSeeker,
-	apiMethod string,
-	floodHandler flood.Handler,
-	data apiRequest,
-	attempt int,
-) (bool, error) {
-	res, err := executeHTTPRequest(
-		ctx,
-		client,
-		url,
-		contentType,
-		body,
-		apiMethod,
-		floodHandler,
-		data,
-	)
+// Decodes API response and returns its result.
+// Returns *goram.APIError if the response is not OK and *goram.HTTPError if the response is not JSON at all.
+func decodeResponse(res *http.Response, apiMethod string) (json.RawMessage, error) {
+	body, err := io.ReadAll(res.Body)
 
 	if err != nil {
-		return false, err
+		return nil, err
 	}
 
-	defer res.Body.Close()
+	response := apiResponse[json.RawMessage]{}
 
-	if res.StatusCode == http.StatusOK {
-		return false, nil
-	}
+	if err := json.Unmarshal(body, &response); err != nil {
+		var syntaxError *json.SyntaxError
 
-	response := &apiResponse[json.RawMessage]{}
+		if errors.As(err, &syntaxError) {
+			return nil, newHTTPError(res, body)
+		}
 
-	if err := json.NewDecoder(res.Body).Decode(response); err != nil {
-		return false, err
+		return nil, err
 	}
 
-	if response.OK {
-		return false, nil
+	if !response.OK {
+		return nil, response.error(apiMethod)
 	}
 
-	if response.ErrorCode != http.StatusTooManyRequests ||
-		response.Parameters == nil ||
-		floodHandler == nil ||
-		attempt == maxRetries-1 {
+	return response.Result, nil
+}
 
-		return false, response.error(apiMethod)
+// Parses response body only in case of an error.
+func decodeVoidResponse(res *http.Response, apiMethod string) (json.RawMessage, error) {
+	if res.StatusCode == http.StatusOK {
+		return nil, nil
 	}
 
-	duration := time.Second * time.Duration(response.Parameters.RetryAfter)
-	floodHandler.Handle(ctx, apiMethod, data, duration)
-
-	return true, nil
+	return decodeResponse(res, apiMethod)
 }
 
-func prepareRequestBody(data apiRequest) (io.ReadSeeker, string) {
-	if data == nil {
-		return nil, "multipart/form-data"
-	}
+// Makes a request, retrying it on transient errors (see BotOptions.RetryPolicy).
+func (b *Bot) doRequest(
+	ctx context.Context,
+	apiMethod string,
+	body io.ReadSeeker,
+	contentType string,
+	decode func(*http.Response, string) (json.RawMessage, error),
+) (json.RawMessage, error) {
+	url := b.baseURL + apiMethod
 
-	buf := bytes.Buffer{}
-	w := multipart.NewWriter(&buf)
-	data.writeMultipart(w)
-	w.Close()
+	for attempt := 0; ; attempt++ {
+		result, err := b.doRequestAttempt(ctx, url, contentType, body, apiMethod, decode)
 
-	return bytes.NewReader(buf.Bytes()), w.FormDataContentType()
+		if err == nil {
+			return result, nil
+		}
+
+		policy := b.Options.RetryPolicy
+
+		if policy == nil || !policy.shouldRetry(apiMethod, attempt, err) || !policy.wait(ctx, apiMethod, attempt, err) {
+			return nil, err
+		}
+
+		if body != nil {
+			body.Seek(0, io.SeekStart)
+		}
+	}
 }
 
-func executeHTTPRequest(
+func (b *Bot) doRequestAttempt(
 	ctx context.Context,
-	client *http.Client,
 	url string,
 	contentType string,
 	body io.ReadSeeker,
 	apiMethod string,
-	floodHandler flood.Handler,
-	data apiRequest,
-) (*http.Response, error) {
+	decode func(*http.Response, string) (json.RawMessage, error),
+) (json.RawMessage, error) {
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, body)
 
 	if err != nil {
@@ -256,16 +213,25 @@ func executeHTTPRequest(
 	}
 
 	req.Header.Set("Content-Type", contentType)
+	res, err := b.Options.Client.Do(req)
 
-	if floodHandler != nil {
-		floodHandler.Enter(ctx, apiMethod, data)
+	if err != nil {
+		return nil, err
 	}
 
-	res, err := client.Do(req)
+	defer res.Body.Close()
+	return decode(res, apiMethod)
+}
 
-	if err != nil {
-		return nil, err
+func prepareRequestBody(data apiRequest) (io.ReadSeeker, string) {
+	if data == nil {
+		return nil, "multipart/form-data"
 	}
 
-	return res, nil
+	buf := bytes.Buffer{}
+	w := multipart.NewWriter(&buf)
+	data.writeMultipart(w)
+	w.Close()
+
+	return bytes.NewReader(buf.Bytes()), w.FormDataContentType()
 }
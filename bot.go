@@ -4,7 +4,9 @@ import (
 	"context"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/TrixiS/goram/flood"
 )
@@ -16,6 +18,8 @@ type BotOptions struct {
 	Client       *http.Client  // Optional. If Client is nil, http.DefaultClient will be used
 	FloodHandler flood.Handler // Optional. If FloodHandler is nil, 429 flood error will be propagated to the caller of a flooded method
 	BaseURL      string        // Optional. If BaseUrl is empty, goram.DefaultAPIBaseURL will be used
+	RetryPolicy  *RetryPolicy  // Optional. If RetryPolicy is nil, transient network and 5xx errors are propagated to the caller
+	Middlewares  []Middleware  // Optional. Request interceptors, called in order for every API request. The first one is the outermost
 }
 
 // Holds all methods of Telegram Bot API.
@@ -25,9 +29,15 @@ type BotOptions struct {
 // If a method call fails, it can return *goram.APIError as the second return value. The returned error can be checked whether it's an API error as easy as:
 //
 // apiError, ok := err.(*goram.APIError)
+//
+// Known errors can be checked with errors.Is, see goram.APIErrorKind:
+//
+// errors.Is(err, goram.ErrBotBlocked)
 type Bot struct {
 	Options BotOptions
 	baseURL string
+	meMu    sync.Mutex
+	me      *User
 }
 
 func NewBot(options BotOptions) *Bot {
@@ -47,6 +57,32 @@ func NewBot(options BotOptions) *Bot {
 	}
 }
 
+// Returns bot user id taken from the token. Returns 0 if the token is malformed.
+func (b *Bot) ID() int64 {
+	rawID, _, _ := strings.Cut(b.Options.Token, ":")
+	id, _ := strconv.ParseInt(rawID, 10, 64)
+	return id
+}
+
+// Returns the bot user. Calls Bot.GetMe() on the first call only, the result is cached after that.
+func (b *Bot) Me(ctx context.Context) (*User, error) {
+	b.meMu.Lock()
+	defer b.meMu.Unlock()
+
+	if b.me != nil {
+		return b.me, nil
+	}
+
+	me, err := b.GetMe(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	b.me = me
+	return me, nil
+}
+
 type ErrDownloadFile struct {
 	Response *http.Response
 	File     *File
@@ -0,0 +1,414 @@
+package broadcast
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/TrixiS/goram"
+	"github.com/TrixiS/goram/flood"
+)
+
+const (
+	DefaultWorkers   = 16
+	DefaultBatchSize = 100
+)
+
+var (
+	ErrCanceled     = errors.New("broadcast is canceled")
+	ErrRunning      = errors.New("broadcast is already running")
+	ErrNoID         = errors.New("broadcast id is required to use a progress store")
+	ErrNoRecipients = errors.New("broadcast recipients are not set")
+	ErrNoMessage    = errors.New("broadcast message is not set")
+)
+
+// Message that gets sent to every recipient. See broadcast.Text(), broadcast.Copy() and broadcast.MediaGroup().
+type Message func(ctx context.Context, bot *goram.Bot, chatID goram.ChatID) error
+
+// Sends a text message. request.ChatID is replaced with the recipient.
+func Text(request goram.SendMessageRequest) Message {
+	return func(ctx context.Context, bot *goram.Bot, chatID goram.ChatID) error {
+		r := request
+		r.ChatID = chatID
+		return bot.SendMessageVoid(ctx, &r)
+	}
+}
+
+// Copies an existing message (for example, a channel post). request.ChatID is replaced with the recipient.
+func Copy(request goram.CopyMessageRequest) Message {
+	return func(ctx context.Context, bot *goram.Bot, chatID goram.ChatID) error {
+		r := request
+		r.ChatID = chatID
+		return bot.CopyMessageVoid(ctx, &r)
+	}
+}
+
+// Sends a media group. request.ChatID is replaced with the recipient.
+//
+// Media must be file ids or URLs, because file readers can be sent only once.
+func MediaGroup(request goram.SendMediaGroupRequest) Message {
+	return func(ctx context.Context, bot *goram.Bot, chatID goram.ChatID) error {
+		r := request
+		r.ChatID = chatID
+		return bot.SendMediaGroupVoid(ctx, &r)
+	}
+}
+
+type Status int
+
+const (
+	StatusSent        Status = iota
+	StatusBlocked            // The bot was blocked by the user, kicked from the chat or can't initiate conversation
+	StatusDeactivated        // The user or the group is deactivated
+	StatusNotFound           // The chat does not exist
+	StatusMigrated           // The group was migrated to a supergroup. Message is sent to the new chat, see Result.MigratedTo
+	StatusFailed             // Any other error
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusSent:
+		return "sent"
+	case StatusBlocked:
+		return "blocked"
+	case StatusDeactivated:
+		return "deactivated"
+	case StatusNotFound:
+		return "not found"
+	case StatusMigrated:
+		return "migrated"
+	}
+
+	return "failed"
+}
+
+// Result of sending the message to a single recipient.
+type Result struct {
+	ChatID     int64
+	Status     Status
+	MigratedTo int64 // New chat id if Status is broadcast.StatusMigrated
+	Err        error // Send error. Can be non-nil for broadcast.StatusMigrated if sending to the new chat failed
+}
+
+// Returns true if the recipient can't receive messages anymore and should be removed from the mailing list.
+func (r *Result) Dead() bool {
+	return r.Status == StatusBlocked || r.Status == StatusDeactivated || r.Status == StatusNotFound
+}
+
+// Counts of results by status.
+type Stats struct {
+	Sent        int
+	Blocked     int
+	Deactivated int
+	NotFound    int
+	Migrated    int
+	Failed      int
+}
+
+func (s *Stats) add(status Status) {
+	switch status {
+	case StatusSent:
+		s.Sent++
+	case StatusBlocked:
+		s.Blocked++
+	case StatusDeactivated:
+		s.Deactivated++
+	case StatusNotFound:
+		s.NotFound++
+	case StatusMigrated:
+		s.Migrated++
+	default:
+		s.Failed++
+	}
+}
+
+type Options struct {
+	ID         string                              // Optional. Broadcast id in the progress store. Required if Store is set
+	Recipients Recipients                          // Required
+	Message    Message                             // Required
+	Store      Store                               // Optional. If Store is nil, progress is not saved and a broadcast can't be resumed
+	Workers    int                                 // Optional. Amount of concurrent senders. Default is broadcast.DefaultWorkers
+	BatchSize  int                                 // Optional. Amount of recipients loaded and checkpointed at once. Default is broadcast.DefaultBatchSize
+	OnResult   func(ctx context.Context, r Result) // Optional. Gets called concurrently from workers for every recipient
+}
+
+// Sends a message to many recipients as fast as the flood limits allow.
+//
+// Messages are sent through the bot's flood handler (see goram.BotOptions.FloodHandler).
+// If the bot has no flood handler, a copy of the bot with flood.Limiter is used.
+//
+// Progress is saved to the store after every batch of recipients, so after a crash Broadcaster.Run()
+// continues from the last saved batch. Recipients of the unfinished batch may receive the message twice.
+type Broadcaster struct {
+	Options Options
+
+	bot     *goram.Bot
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	resume  chan struct{} // not nil while paused
+	stats   Stats
+	running bool
+}
+
+func NewBroadcaster(bot *goram.Bot, options Options) *Broadcaster {
+	if options.Workers <= 0 {
+		options.Workers = DefaultWorkers
+	}
+
+	if options.BatchSize <= 0 {
+		options.BatchSize = DefaultBatchSize
+	}
+
+	if bot.Options.FloodHandler == nil {
+		botOptions := bot.Options
+		botOptions.FloodHandler = flood.NewLimiter(flood.LimiterOptions{})
+		bot = goram.NewBot(botOptions)
+	}
+
+	return &Broadcaster{Options: options, bot: bot}
+}
+
+// Sends the message to all recipients, starting from the saved progress.
+//
+// Returns stats of this run and nil when all recipients are processed,
+// broadcast.ErrCanceled if Broadcaster.Cancel() is called or ctx error if ctx is done.
+// Progress of finished batches is saved in any case, so the broadcast can be resumed by calling Run() again.
+func (b *Broadcaster) Run(ctx context.Context) (Stats, error) {
+	if err := b.validate(); err != nil {
+		return Stats{}, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	b.mu.Lock()
+
+	if b.running {
+		b.mu.Unlock()
+		return Stats{}, ErrRunning
+	}
+
+	b.running, b.cancel, b.stats = true, cancel, Stats{}
+	b.mu.Unlock()
+
+	canceled := false
+
+	defer func() {
+		b.mu.Lock()
+		b.running, b.cancel = false, nil
+		b.mu.Unlock()
+	}()
+
+	offset, err := b.loadOffset(ctx)
+
+	if err != nil {
+		return Stats{}, err
+	}
+
+	for {
+		recipients, err := b.Options.Recipients.Recipients(ctx, offset, b.Options.BatchSize)
+
+		if err != nil {
+			return b.Stats(), err
+		}
+
+		if len(recipients) == 0 {
+			return b.Stats(), nil
+		}
+
+		b.sendBatch(ctx, recipients)
+
+		if ctx.Err() != nil {
+			b.mu.Lock()
+			canceled = b.cancel == nil
+			b.mu.Unlock()
+			break
+		}
+
+		offset += len(recipients)
+
+		if err := b.saveOffset(ctx, offset); err != nil {
+			return b.Stats(), err
+		}
+	}
+
+	if canceled {
+		return b.Stats(), ErrCanceled
+	}
+
+	return b.Stats(), ctx.Err()
+}
+
+// Returns stats of the current (or the last) run.
+func (b *Broadcaster) Stats() Stats {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.stats
+}
+
+// Pauses sending after in-flight messages are sent. Does nothing if already paused.
+func (b *Broadcaster) Pause() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.resume == nil {
+		b.resume = make(chan struct{})
+	}
+}
+
+// Resumes paused sending.
+func (b *Broadcaster) Resume() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.resume != nil {
+		close(b.resume)
+		b.resume = nil
+	}
+}
+
+// Returns true if the broadcast is paused.
+func (b *Broadcaster) Paused() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.resume != nil
+}
+
+// Stops the running broadcast. Broadcaster.Run() returns broadcast.ErrCanceled.
+// The unfinished batch is not checkpointed, so it is sent again on the next run.
+func (b *Broadcaster) Cancel() {
+	b.mu.Lock()
+	cancel := b.cancel
+	b.cancel = nil
+	b.mu.Unlock()
+
+	if cancel != nil {
+		cancel()
+	}
+}
+
+func (b *Broadcaster) validate() error {
+	switch {
+	case b.Options.Recipients == nil:
+		return ErrNoRecipients
+	case b.Options.Message == nil:
+		return ErrNoMessage
+	case b.Options.Store != nil && b.Options.ID == "":
+		return ErrNoID
+	}
+
+	return nil
+}
+
+func (b *Broadcaster) loadOffset(ctx context.Context) (int, error) {
+	if b.Options.Store == nil {
+		return 0, nil
+	}
+
+	return b.Options.Store.LoadProgress(ctx, b.Options.ID)
+}
+
+func (b *Broadcaster) saveOffset(ctx context.Context, offset int) error {
+	if b.Options.Store == nil {
+		return nil
+	}
+
+	return b.Options.Store.SaveProgress(ctx, b.Options.ID, offset)
+}
+
+func (b *Broadcaster) sendBatch(ctx context.Context, recipients []int64) {
+	jobs := make(chan int64)
+	wg := sync.WaitGroup{}
+	wg.Add(b.Options.Workers)
+
+	for i := 0; i < b.Options.Workers; i++ {
+		go func() {
+			defer wg.Done()
+
+			for chatID := range jobs {
+				b.send(ctx, chatID)
+			}
+		}()
+	}
+
+	defer wg.Wait()
+	defer close(jobs)
+
+	for _, chatID := range recipients {
+		if !b.waitResumed(ctx) {
+			return
+		}
+
+		select {
+		case jobs <- chatID:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Blocks while paused. Returns false if ctx is done.
+func (b *Broadcaster) waitResumed(ctx context.Context) bool {
+	b.mu.Lock()
+	resume := b.resume
+	b.mu.Unlock()
+
+	if resume == nil {
+		return ctx.Err() == nil
+	}
+
+	select {
+	case <-resume:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (b *Broadcaster) send(ctx context.Context, chatID int64) {
+	result := Result{ChatID: chatID}
+	err := b.Options.Message(ctx, b.bot, goram.ChatID{ID: chatID})
+
+	var migrateErr *goram.MigrateToChatError
+
+	if errors.As(err, &migrateErr) {
+		result.MigratedTo = migrateErr.ChatID
+		err = b.Options.Message(ctx, b.bot, goram.ChatID{ID: migrateErr.ChatID})
+		result.Status = StatusMigrated
+	} else {
+		result.Status = status(err)
+	}
+
+	result.Err = err
+
+	// Do not count messages interrupted by cancellation, they are sent again on the next run.
+	if err != nil && ctx.Err() != nil {
+		return
+	}
+
+	b.mu.Lock()
+	b.stats.add(result.Status)
+	b.mu.Unlock()
+
+	if b.Options.OnResult != nil {
+		b.Options.OnResult(ctx, result)
+	}
+}
+
+func status(err error) Status {
+	switch {
+	case err == nil:
+		return StatusSent
+	case errors.Is(err, goram.ErrBotBlocked),
+		errors.Is(err, goram.ErrBotKicked),
+		errors.Is(err, goram.ErrCantInitiateConversation):
+		return StatusBlocked
+	case errors.Is(err, goram.ErrUserDeactivated),
+		errors.Is(err, goram.ErrGroupChatDeactivated):
+		return StatusDeactivated
+	case errors.Is(err, goram.ErrChatNotFound):
+		return StatusNotFound
+	}
+
+	return StatusFailed
+}
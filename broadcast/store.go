@@ -0,0 +1,120 @@
+package broadcast
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/TrixiS/goram/internal/fileutil"
+)
+
+// Source of recipients.
+//
+// Recipients must be returned in the same order on every call, so a broadcast can be resumed by offset.
+type Recipients interface {
+	Recipients(ctx context.Context, offset int, limit int) ([]int64, error) // Returns an empty slice if there are no more recipients
+}
+
+// Recipients from a slice of chat ids.
+type SliceRecipients []int64
+
+func (s SliceRecipients) Recipients(ctx context.Context, offset int, limit int) ([]int64, error) {
+	if offset >= len(s) {
+		return nil, nil
+	}
+
+	return s[offset:min(offset+limit, len(s))], nil
+}
+
+// Checkpoints broadcast progress (amount of processed recipients) by broadcast id.
+type Store interface {
+	LoadProgress(ctx context.Context, id string) (int, error) // Returns 0 if there is no saved progress
+	SaveProgress(ctx context.Context, id string, offset int) error
+}
+
+// In-memory progress store. Progress survives broadcaster restarts, but not process restarts.
+type MemoryStore struct {
+	mu       sync.Mutex
+	progress map[string]int
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{progress: make(map[string]int)}
+}
+
+func (m *MemoryStore) LoadProgress(ctx context.Context, id string) (int, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.progress[id], nil
+}
+
+func (m *MemoryStore) SaveProgress(ctx context.Context, id string, offset int) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.progress[id] = offset
+	return nil
+}
+
+// Progress store that keeps progress of all broadcasts in a JSON file. The file is replaced atomically on every save.
+type FileStore struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func NewFileStore(path string) *FileStore {
+	return &FileStore{Path: path}
+}
+
+func (f *FileStore) LoadProgress(ctx context.Context, id string) (int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	progress, err := f.read()
+
+	if err != nil {
+		return 0, err
+	}
+
+	return progress[id], nil
+}
+
+func (f *FileStore) SaveProgress(ctx context.Context, id string, offset int) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	progress, err := f.read()
+
+	if err != nil {
+		return err
+	}
+
+	progress[id] = offset
+	content, err := json.Marshal(progress)
+
+	if err != nil {
+		return err
+	}
+
+	return fileutil.WriteFileAtomic(f.Path, content)
+}
+
+func (f *FileStore) read() (map[string]int, error) {
+	progress := make(map[string]int)
+	content, err := os.ReadFile(f.Path)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return progress, nil
+		}
+
+		return nil, err
+	}
+
+	if err := json.Unmarshal(content, &progress); err != nil {
+		return nil, err
+	}
+
+	return progress, nil
+}
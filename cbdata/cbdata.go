@@ -68,10 +68,15 @@ func Unpack[T any](prefix string, callbackData string) (T, error) {
 // handlers.Data key for cached unpacked callback data
 const Key = "callbackData"
 
+// Returns typed handlers.Data key for cached unpacked callback data of type T. Values are stored under cbdata.Key.
+func DataKey[T any]() handlers.Key[T] {
+	return handlers.NewKey[T](Key)
+}
+
 // Creates callback query filter for callback data. Unpacks callback data and check the prefix.
 // If a query has no data, the created filter returns false.
 // If the prefix matches, the created filter puts unpacked callback data to handler data
-// with "callbackData" key and returns true. Otherwise returns false.
+// with cbdata.DataKey[T]() and returns true. Otherwise returns false.
 func Filter[T any](prefix string) handlers.Filter[*goram.CallbackQuery] {
 	return func(ctx context.Context, bot *goram.Bot, query *goram.CallbackQuery, data handlers.Data) (bool, error) {
 		value, err := Unpack[T](prefix, query.Data)
@@ -84,7 +89,7 @@ func Filter[T any](prefix string) handlers.Filter[*goram.CallbackQuery] {
 			return false, err
 		}
 
-		data[Key] = value
+		DataKey[T]().Set(data, value)
 		return true, nil
 	}
 }
@@ -95,12 +100,8 @@ func FilterFunc[T any](
 	predicate func(data T) bool,
 ) handlers.Filter[*goram.CallbackQuery] {
 	return func(ctx context.Context, bot *goram.Bot, query *goram.CallbackQuery, data handlers.Data) (bool, error) {
-		storedValue, exists := data[Key]
-
-		if exists {
-			if data, ok := storedValue.(T); ok {
-				return predicate(data), nil
-			}
+		if value, ok := DataKey[T]().Get(data); ok {
+			return predicate(value), nil
 		}
 
 		value, err := Unpack[T](prefix, query.Data)
@@ -113,7 +114,7 @@ func FilterFunc[T any](
 			return false, err
 		}
 
-		data[Key] = value
+		DataKey[T]().Set(data, value)
 		return predicate(value), nil
 	}
 }
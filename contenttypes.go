@@ -0,0 +1,226 @@
+// Code generated by goram/internal/gen; DO NOT EDIT.
+
+package goram
+
+type ContentType string
+
+const (
+	ContentTypeText                          ContentType = "text"
+	ContentTypeAnimation                     ContentType = "animation"
+	ContentTypeAudio                         ContentType = "audio"
+	ContentTypeDocument                      ContentType = "document"
+	ContentTypePaidMedia                     ContentType = "paid_media"
+	ContentTypePhoto                         ContentType = "photo"
+	ContentTypeSticker                       ContentType = "sticker"
+	ContentTypeStory                         ContentType = "story"
+	ContentTypeVideo                         ContentType = "video"
+	ContentTypeVideoNote                     ContentType = "video_note"
+	ContentTypeVoice                         ContentType = "voice"
+	ContentTypeChecklist                     ContentType = "checklist"
+	ContentTypeContact                       ContentType = "contact"
+	ContentTypeDice                          ContentType = "dice"
+	ContentTypeGame                          ContentType = "game"
+	ContentTypePoll                          ContentType = "poll"
+	ContentTypeVenue                         ContentType = "venue"
+	ContentTypeLocation                      ContentType = "location"
+	ContentTypeNewChatMembers                ContentType = "new_chat_members"
+	ContentTypeLeftChatMember                ContentType = "left_chat_member"
+	ContentTypeChatOwnerLeft                 ContentType = "chat_owner_left"
+	ContentTypeChatOwnerChanged              ContentType = "chat_owner_changed"
+	ContentTypeNewChatTitle                  ContentType = "new_chat_title"
+	ContentTypeNewChatPhoto                  ContentType = "new_chat_photo"
+	ContentTypeDeleteChatPhoto               ContentType = "delete_chat_photo"
+	ContentTypeGroupChatCreated              ContentType = "group_chat_created"
+	ContentTypeSupergroupChatCreated         ContentType = "supergroup_chat_created"
+	ContentTypeChannelChatCreated            ContentType = "channel_chat_created"
+	ContentTypeMessageAutoDeleteTimerChanged ContentType = "message_auto_delete_timer_changed"
+	ContentTypeMigrateToChatID               ContentType = "migrate_to_chat_id"
+	ContentTypeMigrateFromChatID             ContentType = "migrate_from_chat_id"
+	ContentTypePinnedMessage                 ContentType = "pinned_message"
+	ContentTypeInvoice                       ContentType = "invoice"
+	ContentTypeSuccessfulPayment             ContentType = "successful_payment"
+	ContentTypeRefundedPayment               ContentType = "refunded_payment"
+	ContentTypeUsersShared                   ContentType = "users_shared"
+	ContentTypeChatShared                    ContentType = "chat_shared"
+	ContentTypeGift                          ContentType = "gift"
+	ContentTypeUniqueGift                    ContentType = "unique_gift"
+	ContentTypeGiftUpgradeSent               ContentType = "gift_upgrade_sent"
+	ContentTypeConnectedWebsite              ContentType = "connected_website"
+	ContentTypeWriteAccessAllowed            ContentType = "write_access_allowed"
+	ContentTypePassportData                  ContentType = "passport_data"
+	ContentTypeProximityAlertTriggered       ContentType = "proximity_alert_triggered"
+	ContentTypeBoostAdded                    ContentType = "boost_added"
+	ContentTypeChatBackgroundSet             ContentType = "chat_background_set"
+	ContentTypeChecklistTasksDone            ContentType = "checklist_tasks_done"
+	ContentTypeChecklistTasksAdded           ContentType = "checklist_tasks_added"
+	ContentTypeDirectMessagePriceChanged     ContentType = "direct_message_price_changed"
+	ContentTypeForumTopicCreated             ContentType = "forum_topic_created"
+	ContentTypeForumTopicEdited              ContentType = "forum_topic_edited"
+	ContentTypeForumTopicClosed              ContentType = "forum_topic_closed"
+	ContentTypeForumTopicReopened            ContentType = "forum_topic_reopened"
+	ContentTypeGeneralForumTopicHidden       ContentType = "general_forum_topic_hidden"
+	ContentTypeGeneralForumTopicUnhidden     ContentType = "general_forum_topic_unhidden"
+	ContentTypeGiveawayCreated               ContentType = "giveaway_created"
+	ContentTypeGiveaway                      ContentType = "giveaway"
+	ContentTypeGiveawayWinners               ContentType = "giveaway_winners"
+	ContentTypeGiveawayCompleted             ContentType = "giveaway_completed"
+	ContentTypePaidMessagePriceChanged       ContentType = "paid_message_price_changed"
+	ContentTypeSuggestedPostApproved         ContentType = "suggested_post_approved"
+	ContentTypeSuggestedPostApprovalFailed   ContentType = "suggested_post_approval_failed"
+	ContentTypeSuggestedPostDeclined         ContentType = "suggested_post_declined"
+	ContentTypeSuggestedPostPaid             ContentType = "suggested_post_paid"
+	ContentTypeSuggestedPostRefunded         ContentType = "suggested_post_refunded"
+	ContentTypeVideoChatScheduled            ContentType = "video_chat_scheduled"
+	ContentTypeVideoChatStarted              ContentType = "video_chat_started"
+	ContentTypeVideoChatEnded                ContentType = "video_chat_ended"
+	ContentTypeVideoChatParticipantsInvited  ContentType = "video_chat_participants_invited"
+	ContentTypeWebAppData                    ContentType = "web_app_data"
+)
+
+// Returns type of the message content. Returns an empty string if the content is unknown.
+func (m *Message) ContentType() ContentType {
+	switch {
+	case m.Text != "":
+		return ContentTypeText
+	case m.Animation != nil:
+		return ContentTypeAnimation
+	case m.Audio != nil:
+		return ContentTypeAudio
+	case m.Document != nil:
+		return ContentTypeDocument
+	case m.PaidMedia != nil:
+		return ContentTypePaidMedia
+	case len(m.Photo) > 0:
+		return ContentTypePhoto
+	case m.Sticker != nil:
+		return ContentTypeSticker
+	case m.Story != nil:
+		return ContentTypeStory
+	case m.Video != nil:
+		return ContentTypeVideo
+	case m.VideoNote != nil:
+		return ContentTypeVideoNote
+	case m.Voice != nil:
+		return ContentTypeVoice
+	case m.Checklist != nil:
+		return ContentTypeChecklist
+	case m.Contact != nil:
+		return ContentTypeContact
+	case m.Dice != nil:
+		return ContentTypeDice
+	case m.Game != nil:
+		return ContentTypeGame
+	case m.Poll != nil:
+		return ContentTypePoll
+	case m.Venue != nil:
+		return ContentTypeVenue
+	case m.Location != nil:
+		return ContentTypeLocation
+	case len(m.NewChatMembers) > 0:
+		return ContentTypeNewChatMembers
+	case m.LeftChatMember != nil:
+		return ContentTypeLeftChatMember
+	case m.ChatOwnerLeft != nil:
+		return ContentTypeChatOwnerLeft
+	case m.ChatOwnerChanged != nil:
+		return ContentTypeChatOwnerChanged
+	case m.NewChatTitle != "":
+		return ContentTypeNewChatTitle
+	case len(m.NewChatPhoto) > 0:
+		return ContentTypeNewChatPhoto
+	case m.DeleteChatPhoto:
+		return ContentTypeDeleteChatPhoto
+	case m.GroupChatCreated:
+		return ContentTypeGroupChatCreated
+	case m.SupergroupChatCreated:
+		return ContentTypeSupergroupChatCreated
+	case m.ChannelChatCreated:
+		return ContentTypeChannelChatCreated
+	case m.MessageAutoDeleteTimerChanged != nil:
+		return ContentTypeMessageAutoDeleteTimerChanged
+	case m.MigrateToChatID != 0:
+		return ContentTypeMigrateToChatID
+	case m.MigrateFromChatID != 0:
+		return ContentTypeMigrateFromChatID
+	case m.PinnedMessage != nil:
+		return ContentTypePinnedMessage
+	case m.Invoice != nil:
+		return ContentTypeInvoice
+	case m.SuccessfulPayment != nil:
+		return ContentTypeSuccessfulPayment
+	case m.RefundedPayment != nil:
+		return ContentTypeRefundedPayment
+	case m.UsersShared != nil:
+		return ContentTypeUsersShared
+	case m.ChatShared != nil:
+		return ContentTypeChatShared
+	case m.Gift != nil:
+		return ContentTypeGift
+	case m.UniqueGift != nil:
+		return ContentTypeUniqueGift
+	case m.GiftUpgradeSent != nil:
+		return ContentTypeGiftUpgradeSent
+	case m.ConnectedWebsite != "":
+		return ContentTypeConnectedWebsite
+	case m.WriteAccessAllowed != nil:
+		return ContentTypeWriteAccessAllowed
+	case m.PassportData != nil:
+		return ContentTypePassportData
+	case m.ProximityAlertTriggered != nil:
+		return ContentTypeProximityAlertTriggered
+	case m.BoostAdded != nil:
+		return ContentTypeBoostAdded
+	case m.ChatBackgroundSet != nil:
+		return ContentTypeChatBackgroundSet
+	case m.ChecklistTasksDone != nil:
+		return ContentTypeChecklistTasksDone
+	case m.ChecklistTasksAdded != nil:
+		return ContentTypeChecklistTasksAdded
+	case m.DirectMessagePriceChanged != nil:
+		return ContentTypeDirectMessagePriceChanged
+	case m.ForumTopicCreated != nil:
+		return ContentTypeForumTopicCreated
+	case m.ForumTopicEdited != nil:
+		return ContentTypeForumTopicEdited
+	case m.ForumTopicClosed != nil:
+		return ContentTypeForumTopicClosed
+	case m.ForumTopicReopened != nil:
+		return ContentTypeForumTopicReopened
+	case m.GeneralForumTopicHidden != nil:
+		return ContentTypeGeneralForumTopicHidden
+	case m.GeneralForumTopicUnhidden != nil:
+		return ContentTypeGeneralForumTopicUnhidden
+	case m.GiveawayCreated != nil:
+		return ContentTypeGiveawayCreated
+	case m.Giveaway != nil:
+		return ContentTypeGiveaway
+	case m.GiveawayWinners != nil:
+		return ContentTypeGiveawayWinners
+	case m.GiveawayCompleted != nil:
+		return ContentTypeGiveawayCompleted
+	case m.PaidMessagePriceChanged != nil:
+		return ContentTypePaidMessagePriceChanged
+	case m.SuggestedPostApproved != nil:
+		return ContentTypeSuggestedPostApproved
+	case m.SuggestedPostApprovalFailed != nil:
+		return ContentTypeSuggestedPostApprovalFailed
+	case m.SuggestedPostDeclined != nil:
+		return ContentTypeSuggestedPostDeclined
+	case m.SuggestedPostPaid != nil:
+		return ContentTypeSuggestedPostPaid
+	case m.SuggestedPostRefunded != nil:
+		return ContentTypeSuggestedPostRefunded
+	case m.VideoChatScheduled != nil:
+		return ContentTypeVideoChatScheduled
+	case m.VideoChatStarted != nil:
+		return ContentTypeVideoChatStarted
+	case m.VideoChatEnded != nil:
+		return ContentTypeVideoChatEnded
+	case m.VideoChatParticipantsInvited != nil:
+		return ContentTypeVideoChatParticipantsInvited
+	case m.WebAppData != nil:
+		return ContentTypeWebAppData
+	}
+
+	return ""
+}
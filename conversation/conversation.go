@@ -0,0 +1,318 @@
+package conversation
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/TrixiS/goram"
+	"github.com/TrixiS/goram/handlers"
+)
+
+const DefaultTimeout = 5 * time.Minute
+
+var (
+	ErrTimeout  = errors.New("conversation: timed out")
+	ErrCanceled = errors.New("conversation: canceled")
+)
+
+// Reports whether the update is the one a conversation waits for.
+type MatchFunc func(ctx context.Context, bot *goram.Bot, update *goram.Update) (bool, error)
+
+type Options struct {
+	Timeout        time.Duration // Optional. Max time to wait for an update. Default is conversation.DefaultTimeout, negative means no timeout
+	CancelKeywords []string      // Optional. Message texts (case-insensitive) that cancel all waits of the user in the chat, like "/cancel"
+}
+
+type key struct {
+	chatID int64
+	userID int64
+}
+
+type waitResult struct {
+	update *goram.Update
+	err    error
+}
+
+type waiter struct {
+	match  MatchFunc
+	result chan waitResult
+}
+
+// Lets a handler wait for the next update of the same user in the same chat,
+// so linear dialogs can be written as plain code instead of fsm states.
+//
+//	manager := conversation.NewManager(conversation.Options{CancelKeywords: []string{"/cancel"}})
+//	manager.Register(router)
+//
+//	router.Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data handlers.Data) error {
+//		conv := manager.FromMessage(message)
+//		// ask for the name
+//		answer, err := conv.WaitMessage(ctx, filters.ContentType(goram.ContentTypeText))
+//		...
+//	}, filters.Command("register"))
+//
+// Waiting blocks the handler, so updates are intercepted before they are queued by handlers.Dispatcher
+// (see handlers.Router.Intercept()), and the dispatcher worker is released before waiting (see handlers.ReleaseWorker()).
+// So handlers.Dispatcher.FeedWait() does not wait for waiting handlers and the poller keeps fetching replies.
+// Feeding updates to the router synchronously (like webhook.RouterFunc does)
+// blocks the update source while a handler waits, use a dispatcher in that case.
+type Manager struct {
+	Options Options
+
+	mu      sync.Mutex
+	waiters map[key][]*waiter
+}
+
+func NewManager(options Options) *Manager {
+	if options.Timeout == 0 {
+		options.Timeout = DefaultTimeout
+	}
+
+	return &Manager{
+		Options: options,
+		waiters: make(map[key][]*waiter),
+	}
+}
+
+// Adds the manager to the root router interceptors.
+// Message and callback query updates are marked as used, so they are in router.GetUsedUpdateTypes().
+func (m *Manager) Register(router *handlers.Router) {
+	router.Intercept(m.Intercept).UseUpdateTypes(goram.UpdateMessage, goram.UpdateCallbackQuery)
+}
+
+// handlers.InterceptFunc that passes the update to the first matching wait of its chat and user.
+// Cancel keywords cancel all waits of the chat and user. In both cases the update is consumed.
+//
+// Updates nobody waits for are not consumed.
+func (m *Manager) Intercept(ctx context.Context, bot *goram.Bot, update *goram.Update) bool {
+	k, ok := updateKey(update)
+
+	if !ok {
+		return false
+	}
+
+	m.mu.Lock()
+	waiters := slices.Clone(m.waiters[k])
+	m.mu.Unlock()
+
+	if len(waiters) == 0 {
+		return false
+	}
+
+	if m.isCancel(update) {
+		for _, w := range waiters {
+			m.resolve(k, w, waitResult{err: ErrCanceled})
+		}
+
+		return true
+	}
+
+	for _, w := range waiters {
+		ok, err := w.match(ctx, bot, update)
+
+		if err != nil {
+			m.resolve(k, w, waitResult{err: err})
+			continue
+		}
+
+		// the wait could be resolved concurrently, then the update goes to the next one
+		if ok && m.resolve(k, w, waitResult{update: update}) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns a conversation with the user in the chat. userID is 0 for messages sent on behalf of a chat.
+func (m *Manager) Conversation(chatID int64, userID int64) *Conversation {
+	return &Conversation{ChatID: chatID, UserID: userID, manager: m}
+}
+
+// Returns a conversation with the message sender in the message chat.
+func (m *Manager) FromMessage(message *goram.Message) *Conversation {
+	k, _ := messageKey(message)
+	return m.Conversation(k.chatID, k.userID)
+}
+
+// Returns a conversation with the user who pressed the button in the chat of the button message.
+func (m *Manager) FromCallbackQuery(query *goram.CallbackQuery) *Conversation {
+	return m.Conversation(query.ChatID().ID, query.From.ID)
+}
+
+// Removes the waiter and sends the result to it. Returns false if the waiter has already been removed.
+func (m *Manager) resolve(k key, w *waiter, result waitResult) bool {
+	if !m.remove(k, w) {
+		return false
+	}
+
+	w.result <- result
+	return true
+}
+
+func (m *Manager) remove(k key, w *waiter) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	waiters := m.waiters[k]
+	i := slices.Index(waiters, w)
+
+	if i == -1 {
+		return false
+	}
+
+	if len(waiters) == 1 {
+		delete(m.waiters, k)
+	} else {
+		m.waiters[k] = slices.Delete(waiters, i, i+1)
+	}
+
+	return true
+}
+
+func (m *Manager) isCancel(update *goram.Update) bool {
+	if update.Message == nil || update.Message.Text == "" {
+		return false
+	}
+
+	text := strings.TrimSpace(update.Message.Text)
+
+	for _, keyword := range m.Options.CancelKeywords {
+		if strings.EqualFold(text, keyword) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Dialog with a user in a chat.
+type Conversation struct {
+	ChatID int64
+	UserID int64
+
+	manager *Manager
+}
+
+// Waits for the next update of the user in the chat that matches.
+//
+// Returns conversation.ErrTimeout after Options.Timeout, conversation.ErrCanceled if the user sent a cancel keyword
+// and ctx error if ctx is done. Use ctx deadline for a shorter timeout.
+// Only message and callback query updates are waited for.
+// If the handler runs on handlers.Dispatcher, its worker is released, see handlers.ReleaseWorker().
+func (c *Conversation) Wait(ctx context.Context, match MatchFunc) (*goram.Update, error) {
+	k := key{chatID: c.ChatID, userID: c.UserID}
+	w := &waiter{match: match, result: make(chan waitResult, 1)}
+	handlers.ReleaseWorker(ctx)
+
+	c.manager.mu.Lock()
+	c.manager.waiters[k] = append(c.manager.waiters[k], w)
+	c.manager.mu.Unlock()
+
+	var timeout <-chan time.Time
+
+	if c.manager.Options.Timeout > 0 {
+		timer := time.NewTimer(c.manager.Options.Timeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	var err error
+
+	select {
+	case result := <-w.result:
+		return result.update, result.err
+	case <-timeout:
+		err = ErrTimeout
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+
+	// the wait has been resolved right before it was removed
+	if !c.manager.remove(k, w) {
+		result := <-w.result
+		return result.update, result.err
+	}
+
+	return nil, err
+}
+
+// Waits for the next message that passes all filters. See .Wait().
+func (c *Conversation) WaitMessage(ctx context.Context, filters ...handlers.Filter[*goram.Message]) (*goram.Message, error) {
+	update, err := c.Wait(ctx, matchFilters(func(u *goram.Update) *goram.Message { return u.Message }, filters))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return update.Message, nil
+}
+
+// Waits for the next callback query that passes all filters. See .Wait().
+//
+// Note that the query still has to be answered.
+func (c *Conversation) WaitCallbackQuery(
+	ctx context.Context,
+	filters ...handlers.Filter[*goram.CallbackQuery],
+) (*goram.CallbackQuery, error) {
+	update, err := c.Wait(ctx, matchFilters(func(u *goram.Update) *goram.CallbackQuery { return u.CallbackQuery }, filters))
+
+	if err != nil {
+		return nil, err
+	}
+
+	return update.CallbackQuery, nil
+}
+
+// Creates MatchFunc that passes if get returns non-nil and all filters pass. Every filter call gets empty handlers.Data.
+func matchFilters[T comparable](get func(*goram.Update) T, filters []handlers.Filter[T]) MatchFunc {
+	return func(ctx context.Context, bot *goram.Bot, update *goram.Update) (bool, error) {
+		var zero T
+		value := get(update)
+
+		if value == zero {
+			return false, nil
+		}
+
+		data := handlers.Data{}
+
+		for _, filter := range filters {
+			ok, err := filter(ctx, bot, value, data)
+
+			if err != nil || !ok {
+				return false, err
+			}
+		}
+
+		return true, nil
+	}
+}
+
+func updateKey(update *goram.Update) (key, bool) {
+	switch {
+	case update.Message != nil:
+		return messageKey(update.Message)
+	case update.CallbackQuery != nil:
+		return key{chatID: update.CallbackQuery.ChatID().ID, userID: update.CallbackQuery.From.ID}, true
+	}
+
+	return key{}, false
+}
+
+func messageKey(message *goram.Message) (key, bool) {
+	if message.Chat == nil {
+		return key{}, false
+	}
+
+	k := key{chatID: message.Chat.ID}
+
+	if message.From != nil {
+		k.userID = message.From.ID
+	}
+
+	return k, true
+}
@@ -0,0 +1,91 @@
+package conversation
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/TrixiS/goram"
+	"github.com/TrixiS/goram/filters"
+	"github.com/TrixiS/goram/handlers"
+)
+
+func testMessage(updateID int64, chatID int64, text string) goram.Update {
+	return goram.Update{
+		UpdateID: updateID,
+		Message: &goram.Message{
+			MessageID: int(updateID),
+			Chat:      &goram.Chat{ID: chatID},
+			From:      &goram.User{ID: chatID},
+			Text:      text,
+		},
+	}
+}
+
+func TestWaitWithDispatcherFeedWait(t *testing.T) {
+	manager := NewManager(Options{Timeout: time.Second * 5})
+	router := handlers.NewRouter(handlers.RouterOptions{})
+	manager.Register(router)
+
+	answers := make(chan string, 1)
+	other := make(chan int64, 1)
+
+	router.Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data handlers.Data) error {
+		answer, err := manager.FromMessage(message).WaitMessage(ctx)
+
+		if err != nil {
+			return err
+		}
+
+		answers <- answer.Text
+		return nil
+	}, filters.Text("/register"))
+
+	router.Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data handlers.Data) error {
+		other <- message.Chat.ID
+		return nil
+	})
+
+	// a single worker, so the second chat would be stalled by the waiting handler
+	d := handlers.NewDispatcher(context.Background(), nil, router, handlers.DispatcherOptions{Workers: 1})
+	defer d.Stop()
+
+	feedWait := func(update goram.Update) {
+		t.Helper()
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+
+		if err := d.FeedWait(ctx, []goram.Update{update}); err != nil {
+			t.Fatalf("FeedWait(%q) error = %v", update.Message.Text, err)
+		}
+	}
+
+	feedWait(testMessage(1, 1, "/register"))
+	feedWait(testMessage(2, 2, "hello"))
+
+	select {
+	case chatID := <-other:
+		if chatID != 2 {
+			t.Fatalf("got message of chat %d, want 2", chatID)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("update of another chat is stalled by the waiting handler")
+	}
+
+	feedWait(testMessage(3, 1, "bob"))
+
+	select {
+	case answer := <-answers:
+		if answer != "bob" {
+			t.Fatalf("got answer %q, want %q", answer, "bob")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("waiting handler did not get the answer")
+	}
+
+	select {
+	case chatID := <-other:
+		t.Fatalf("the answer of chat %d was routed to handlers", chatID)
+	default:
+	}
+}
@@ -0,0 +1,202 @@
+package deeplink
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/TrixiS/goram"
+	"github.com/TrixiS/goram/handlers"
+)
+
+const (
+	Delim            = '_'
+	MaxPayloadLength = 64 // Max length of start parameter allowed by Telegram
+	SignatureSize    = 8  // Size of truncated HMAC-SHA256 signature in bytes
+)
+
+var (
+	ErrInvalidPrefix    = errors.New("invalid prefix")
+	ErrInvalidSignature = errors.New("invalid signature")
+	ErrPayloadTooLong   = errors.New("payload is longer than 64 characters")
+)
+
+var (
+	encoding = base64.RawURLEncoding
+	order    = binary.LittleEndian
+)
+
+// Returns handlers.Data key for unpacked deep link payload of type T. See filters.DeepLink().
+func Key[T any]() handlers.Key[T] {
+	return handlers.NewKey[T]("deepLink")
+}
+
+// Packs start parameter payload. Works the same as cbdata.Pack(), but the result contains
+// only A-Z, a-z, 0-9, "_" and "-", which are the only characters allowed in start parameters.
+//
+// Prefix should not contain '_' since it is used as a delimiter.
+//
+// Returns goram/deeplink.ErrPayloadTooLong if the payload does not fit 64 characters. Panics if T can't be binary encoded.
+func Pack[T any](prefix string, value T) (string, error) {
+	return pack(prefix, value, nil)
+}
+
+// Unpacks a payload packed by deeplink.Pack().
+//
+// If prefixes do not match, returns deeplink.ErrInvalidPrefix.
+// Otherwise returns encoded value and a binary read error, if occured.
+func Unpack[T any](prefix string, payload string) (T, error) {
+	return unpack[T](prefix, payload, nil)
+}
+
+// Does the same as deeplink.Pack(), but also signs the payload with HMAC-SHA256 truncated to 8 bytes,
+// so users can't forge payloads (for example, referrer ids).
+func PackSigned[T any](key []byte, prefix string, value T) (string, error) {
+	return pack(prefix, value, key)
+}
+
+// Unpacks a payload packed by deeplink.PackSigned(). Returns deeplink.ErrInvalidSignature if the signature does not match.
+func UnpackSigned[T any](key []byte, prefix string, payload string) (T, error) {
+	return unpack[T](prefix, payload, key)
+}
+
+func pack[T any](prefix string, value T, key []byte) (string, error) {
+	buf := &bytes.Buffer{}
+
+	if err := binary.Write(buf, order, value); err != nil {
+		panic(err)
+	}
+
+	raw := buf.Bytes()
+
+	if key != nil {
+		raw = append(raw, sign(key, prefix, raw)...)
+	}
+
+	payload := prefix + string(Delim) + encoding.EncodeToString(raw)
+
+	if len(payload) > MaxPayloadLength {
+		return "", ErrPayloadTooLong
+	}
+
+	return payload, nil
+}
+
+func unpack[T any](prefix string, payload string, key []byte) (T, error) {
+	var value T
+
+	payloadPrefix, encoded, ok := strings.Cut(payload, string(Delim))
+
+	if !ok || payloadPrefix != prefix || encoded == "" {
+		return value, ErrInvalidPrefix
+	}
+
+	raw, err := encoding.DecodeString(encoded)
+
+	if err != nil {
+		return value, err
+	}
+
+	if key != nil {
+		if len(raw) < SignatureSize {
+			return value, ErrInvalidSignature
+		}
+
+		signature := raw[len(raw)-SignatureSize:]
+		raw = raw[:len(raw)-SignatureSize]
+
+		if !hmac.Equal(signature, sign(key, prefix, raw)) {
+			return value, ErrInvalidSignature
+		}
+	}
+
+	err = binary.Read(bytes.NewReader(raw), order, &value)
+	return value, err
+}
+
+func sign(key []byte, prefix string, raw []byte) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(prefix))
+	mac.Write([]byte{Delim})
+	mac.Write(raw)
+	return mac.Sum(nil)[:SignatureSize]
+}
+
+// Creates https://t.me/<bot>?start=<payload> link. Opens a private chat with the bot.
+func Start(botUsername string, payload string) string {
+	return link(botUsername, "", "start="+url.QueryEscape(payload))
+}
+
+// Creates https://t.me/<bot>?startgroup=<payload> link. Asks the user to add the bot to a group.
+// rights are optional administrator rights requested for the bot.
+func StartGroup(botUsername string, payload string, rights *goram.ChatAdministratorRights) string {
+	return link(botUsername, "", "startgroup="+url.QueryEscape(payload)+adminQuery(rights))
+}
+
+// Creates https://t.me/<bot>?startchannel link. Asks the user to add the bot to a channel as an administrator.
+func StartChannel(botUsername string, rights *goram.ChatAdministratorRights) string {
+	return link(botUsername, "", "startchannel"+adminQuery(rights))
+}
+
+// Creates https://t.me/<bot>/<app>?startapp=<payload> link. Opens the Mini App.
+// If appName is empty, opens the main Mini App of the bot: https://t.me/<bot>?startapp=<payload>
+func StartApp(botUsername string, appName string, payload string) string {
+	return link(botUsername, appName, "startapp="+url.QueryEscape(payload))
+}
+
+func link(botUsername string, path string, query string) string {
+	u := "https://t.me/" + strings.TrimPrefix(botUsername, "@")
+
+	if path != "" {
+		u += "/" + path
+	}
+
+	return u + "?" + query
+}
+
+// Returns "&admin=right1+right2" or an empty string if there are no rights.
+func adminQuery(rights *goram.ChatAdministratorRights) string {
+	if rights == nil {
+		return ""
+	}
+
+	names := []string{}
+
+	for _, r := range []struct {
+		ok   bool
+		name string
+	}{
+		{rights.CanChangeInfo, "change_info"},
+		{rights.CanPostMessages, "post_messages"},
+		{rights.CanEditMessages, "edit_messages"},
+		{rights.CanDeleteMessages, "delete_messages"},
+		{rights.CanRestrictMembers, "restrict_members"},
+		{rights.CanInviteUsers, "invite_users"},
+		{rights.CanPinMessages, "pin_messages"},
+		{rights.CanManageTopics, "manage_topics"},
+		{rights.CanPromoteMembers, "promote_members"},
+		{rights.CanManageVideoChats, "manage_video_chats"},
+		{rights.IsAnonymous, "anonymous"},
+		{rights.CanManageChat, "manage_chat"},
+		{rights.CanPostStories, "post_stories"},
+		{rights.CanEditStories, "edit_stories"},
+		{rights.CanDeleteStories, "delete_stories"},
+		{rights.CanManageDirectMessages, "manage_direct_messages"},
+		{rights.CanManageTags, "manage_tags"},
+	} {
+		if r.ok {
+			names = append(names, r.name)
+		}
+	}
+
+	if len(names) == 0 {
+		return ""
+	}
+
+	return "&admin=" + strings.Join(names, "+")
+}
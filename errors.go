@@ -0,0 +1,180 @@
+package goram
+
+import (
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Known kind of Telegram API error. Use it with errors.Is:
+//
+// errors.Is(err, goram.ErrBotBlocked)
+//
+// An error matches a kind if error codes are equal (or kind code is 0)
+// and the error description contains kind description (case-insensitive).
+type APIErrorKind struct {
+	Code        int    // 0 matches any code
+	Description string // Lowercase part of the description. Empty string matches any description
+}
+
+func (k *APIErrorKind) Error() string {
+	if k.Description == "" {
+		return "telegram api error " + strconv.Itoa(k.Code)
+	}
+
+	return k.Description
+}
+
+func (k *APIErrorKind) match(a *APIError) bool {
+	if a.ErrorCode == 0 || (k.Code != 0 && k.Code != a.ErrorCode) {
+		return false
+	}
+
+	return strings.Contains(strings.ToLower(a.Description), k.Description)
+}
+
+var (
+	ErrBadRequest      = &APIErrorKind{Code: 400}
+	ErrUnauthorized    = &APIErrorKind{Code: 401}
+	ErrForbidden       = &APIErrorKind{Code: 403}
+	ErrNotFound        = &APIErrorKind{Code: 404}
+	ErrConflict        = &APIErrorKind{Code: 409}
+	ErrTooManyRequests = &APIErrorKind{Code: 429}
+
+	ErrBotBlocked                  = &APIErrorKind{Code: 403, Description: "bot was blocked by the user"}
+	ErrBotKicked                   = &APIErrorKind{Code: 403, Description: "bot was kicked from"}
+	ErrUserDeactivated             = &APIErrorKind{Code: 403, Description: "user is deactivated"}
+	ErrCantInitiateConversation    = &APIErrorKind{Code: 403, Description: "bot can't initiate conversation with a user"}
+	ErrNotEnoughRights             = &APIErrorKind{Description: "not enough rights"}
+	ErrChatNotFound                = &APIErrorKind{Code: 400, Description: "chat not found"}
+	ErrUserNotFound                = &APIErrorKind{Code: 400, Description: "user not found"}
+	ErrMessageNotModified          = &APIErrorKind{Code: 400, Description: "message is not modified"}
+	ErrMessageToEditNotFound       = &APIErrorKind{Code: 400, Description: "message to edit not found"}
+	ErrMessageToDeleteNotFound     = &APIErrorKind{Code: 400, Description: "message to delete not found"}
+	ErrMessageToForwardNotFound    = &APIErrorKind{Code: 400, Description: "message to forward not found"}
+	ErrMessageToCopyNotFound       = &APIErrorKind{Code: 400, Description: "message to copy not found"}
+	ErrMessageCantBeEdited         = &APIErrorKind{Code: 400, Description: "message can't be edited"}
+	ErrMessageCantBeDeleted        = &APIErrorKind{Code: 400, Description: "message can't be deleted"}
+	ErrMessageTextEmpty            = &APIErrorKind{Code: 400, Description: "message text is empty"}
+	ErrMessageTooLong              = &APIErrorKind{Code: 400, Description: "message is too long"}
+	ErrMigrateToChat               = &APIErrorKind{Code: 400, Description: "group chat was upgraded to a supergroup chat"}
+	ErrQueryTooOld                 = &APIErrorKind{Code: 400, Description: "query is too old"}
+	ErrWrongFileID                 = &APIErrorKind{Code: 400, Description: "wrong file identifier"}
+	ErrInvalidFileID               = &APIErrorKind{Code: 400, Description: "invalid file_id"}
+	ErrTopicClosed                 = &APIErrorKind{Code: 400, Description: "topic_closed"}
+	ErrMessageThreadNotFound       = &APIErrorKind{Code: 400, Description: "message thread not found"}
+	ErrChatAdminRequired           = &APIErrorKind{Code: 400, Description: "chat_admin_required"}
+	ErrButtonDataInvalid           = &APIErrorKind{Code: 400, Description: "button_data_invalid"}
+	ErrCantParseEntities           = &APIErrorKind{Code: 400, Description: "can't parse entities"}
+	ErrReplyMarkupTooLong          = &APIErrorKind{Code: 400, Description: "reply markup is too long"}
+	ErrPeerIDInvalid               = &APIErrorKind{Code: 400, Description: "peer_id_invalid"}
+	ErrGroupChatDeactivated        = &APIErrorKind{Code: 403, Description: "group chat was deactivated"}
+	ErrBotIsNotMember              = &APIErrorKind{Code: 403, Description: "bot is not a member"}
+	ErrTerminatedByOtherGetUpdates = &APIErrorKind{Code: 409, Description: "terminated by other getupdates request"}
+	ErrWebhookIsActive             = &APIErrorKind{Code: 409, Description: "can't use getupdates method while webhook is active"}
+)
+
+// Known API error kinds. More specific kinds go first, see APIError.Kind().
+var apiErrorKinds = []*APIErrorKind{
+	ErrBotBlocked,
+	ErrBotKicked,
+	ErrUserDeactivated,
+	ErrCantInitiateConversation,
+	ErrNotEnoughRights,
+	ErrChatNotFound,
+	ErrUserNotFound,
+	ErrMessageNotModified,
+	ErrMessageToEditNotFound,
+	ErrMessageToDeleteNotFound,
+	ErrMessageToForwardNotFound,
+	ErrMessageToCopyNotFound,
+	ErrMessageCantBeEdited,
+	ErrMessageCantBeDeleted,
+	ErrMessageTextEmpty,
+	ErrMessageTooLong,
+	ErrMigrateToChat,
+	ErrQueryTooOld,
+	ErrWrongFileID,
+	ErrInvalidFileID,
+	ErrTopicClosed,
+	ErrMessageThreadNotFound,
+	ErrChatAdminRequired,
+	ErrButtonDataInvalid,
+	ErrCantParseEntities,
+	ErrReplyMarkupTooLong,
+	ErrPeerIDInvalid,
+	ErrGroupChatDeactivated,
+	ErrBotIsNotMember,
+	ErrTerminatedByOtherGetUpdates,
+	ErrWebhookIsActive,
+	ErrBadRequest,
+	ErrUnauthorized,
+	ErrForbidden,
+	ErrNotFound,
+	ErrConflict,
+	ErrTooManyRequests,
+}
+
+// Returns the first known kind that matches the error or nil if the error is unknown.
+func (a *APIError) Kind() *APIErrorKind {
+	for _, kind := range apiErrorKinds {
+		if kind.match(a) {
+			return kind
+		}
+	}
+
+	return nil
+}
+
+// Implements errors.Is support for *goram.APIErrorKind targets.
+func (a *APIError) Is(target error) bool {
+	kind, ok := target.(*APIErrorKind)
+
+	if !ok {
+		return false
+	}
+
+	if kind == ErrMigrateToChat && a.Parameters != nil && a.Parameters.MigrateToChatID != 0 {
+		return true
+	}
+
+	return kind.match(a)
+}
+
+// Implements errors.As support for *goram.MigrateToChatError and *goram.FloodError targets.
+func (a *APIError) As(target any) bool {
+	switch t := target.(type) {
+	case **MigrateToChatError:
+		if a.Parameters == nil || a.Parameters.MigrateToChatID == 0 {
+			return false
+		}
+
+		*t = &MigrateToChatError{APIError: a, ChatID: a.Parameters.MigrateToChatID}
+		return true
+	case **FloodError:
+		if a.Parameters == nil || a.Parameters.RetryAfter == 0 {
+			return false
+		}
+
+		*t = &FloodError{APIError: a, RetryAfter: time.Duration(a.Parameters.RetryAfter) * time.Second}
+		return true
+	}
+
+	return false
+}
+
+// The group has been migrated to a supergroup. Use errors.As to get it from an error:
+//
+// var migrateErr *goram.MigrateToChatError
+//
+// if errors.As(err, &migrateErr) { newChatID := migrateErr.ChatID }
+type MigrateToChatError struct {
+	*APIError
+	ChatID int64 // New supergroup id
+}
+
+// Flood control exceeded. Use errors.As to get it from an error. See goram.MigrateToChatError.
+type FloodError struct {
+	*APIError
+	RetryAfter time.Duration
+}
@@ -35,13 +35,11 @@ func main() {
 		MaxErrors:     3,
 	})
 
-	for updates := range updatesChan {
-		go func(updates []goram.Update) {
-			found, err := router.FeedUpdates(ctx, bot, updates, handlers.Data{})
-
-			if found && err != nil {
-				fmt.Println("handler error", err)
-			}
-		}(updates)
-	}
+	dispatcher := handlers.NewDispatcher(ctx, bot, router, handlers.DispatcherOptions{
+		OnError: func(ctx context.Context, bot *goram.Bot, updates []goram.Update, err error) {
+			fmt.Println("handler error", err)
+		},
+	})
+
+	dispatcher.Run(ctx, updatesChan)
 }
@@ -2,13 +2,13 @@ package main
 
 import (
 	"context"
-	"encoding/json"
-	"net/http"
+	"fmt"
 	"os"
+	"os/signal"
 
 	"github.com/TrixiS/goram"
 	"github.com/TrixiS/goram/examples/routes"
-	"github.com/TrixiS/goram/handlers"
+	"github.com/TrixiS/goram/webhook"
 )
 
 var (
@@ -19,41 +19,25 @@ var (
 )
 
 func main() {
-	ctx := context.Background()
-	bot := goram.NewBot(goram.BotOptions{Token: token})
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
 
-	router := routes.CreateRouter(0)
+	bot := goram.NewBot(goram.BotOptions{Token: token})
 
-	err := bot.SetWebhookVoid(ctx, &goram.SetWebhookRequest{
+	server := webhook.NewServer(webhook.ServerOptions{
+		Bot:                bot,
 		URL:                url,
+		ListenAddr:         listenAddr,
 		SecretToken:        secret,
+		Router:             routes.CreateRouter(0),
 		DropPendingUpdates: true,
-		AllowedUpdates:     router.GetUsedUpdateTypes(),
+		DeleteWebhook:      true,
+		OnError: func(ctx context.Context, update *goram.Update, err error) {
+			fmt.Println("handler error", err)
+		},
 	})
 
-	if err != nil {
+	if err := server.Run(ctx); err != nil {
 		panic(err)
 	}
-
-	http.HandleFunc("/updates", func(w http.ResponseWriter, r *http.Request) {
-		secretHeader := r.Header.Get(goram.WebhookSecretHeaderKey)
-
-		if secretHeader != secret {
-			w.WriteHeader(http.StatusForbidden)
-			return
-		}
-
-		update := goram.Update{}
-
-		if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-
-		router.FeedUpdate(ctx, bot, &update, handlers.Data{})
-
-		w.WriteHeader(http.StatusOK)
-	})
-
-	http.ListenAndServe(listenAddr, nil)
 }
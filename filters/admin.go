@@ -0,0 +1,237 @@
+package filters
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/TrixiS/goram"
+	"github.com/TrixiS/goram/handlers"
+)
+
+const DefaultAdminCacheTTL = time.Minute * 5
+
+const (
+	memberStatusCreator       = "creator"
+	memberStatusAdministrator = "administrator"
+)
+
+// Cache of chat administrators used by filters.IsAdmin, filters.HasRights() and filters.BotHasRights().
+// Call DefaultAdminCache.Register(router) to invalidate it on chat member updates.
+var DefaultAdminCache = NewAdminCache(DefaultAdminCacheTTL)
+
+// TTL cache of Bot.GetChatAdministrators() results.
+//
+// A chat is refetched after TTL expires or after it gets invalidated by a chat member update (see AdminCache.Register()).
+type AdminCache struct {
+	TTL time.Duration
+
+	mu    sync.Mutex
+	chats map[int64]*adminCacheEntry
+}
+
+type adminCacheEntry struct {
+	mu      sync.Mutex // Held while fetching, so concurrent filters make a single request
+	admins  []goram.ChatMember
+	expires time.Time
+}
+
+func NewAdminCache(ttl time.Duration) *AdminCache {
+	return &AdminCache{TTL: ttl, chats: make(map[int64]*adminCacheEntry)}
+}
+
+// Returns chat administrators, including the owner.
+func (c *AdminCache) Administrators(ctx context.Context, bot *goram.Bot, chatID int64) ([]goram.ChatMember, error) {
+	c.mu.Lock()
+	entry := c.chats[chatID]
+
+	if entry == nil {
+		entry = &adminCacheEntry{}
+		c.chats[chatID] = entry
+	}
+
+	c.mu.Unlock()
+
+	entry.mu.Lock()
+	defer entry.mu.Unlock()
+
+	if entry.admins != nil && time.Now().Before(entry.expires) {
+		return entry.admins, nil
+	}
+
+	admins, err := bot.GetChatAdministrators(ctx, &goram.GetChatAdministratorsRequest{ChatID: goram.ChatID{ID: chatID}})
+
+	if err != nil {
+		return nil, err
+	}
+
+	entry.admins, entry.expires = admins, time.Now().Add(c.TTL)
+	return admins, nil
+}
+
+// Returns chat member of the administrator or nil if the user is not an administrator.
+func (c *AdminCache) Administrator(ctx context.Context, bot *goram.Bot, chatID int64, userID int64) (*goram.ChatMember, error) {
+	admins, err := c.Administrators(ctx, bot, chatID)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range admins {
+		if admins[i].User != nil && admins[i].User.ID == userID {
+			return &admins[i], nil
+		}
+	}
+
+	return nil, nil
+}
+
+// Removes the chat from the cache.
+func (c *AdminCache) Invalidate(chatID int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.chats, chatID)
+}
+
+// Adds outer middlewares to the router that invalidate the cache when administrators
+// or the bot rights change. Also makes the router use "chat_member" and "my_chat_member" update types.
+func (c *AdminCache) Register(router *handlers.Router) *handlers.Router {
+	invalidate := func(next handlers.Func[*goram.ChatMemberUpdated]) handlers.Func[*goram.ChatMemberUpdated] {
+		return func(ctx context.Context, bot *goram.Bot, update *goram.ChatMemberUpdated, data handlers.Data) error {
+			if update.Chat != nil && (isAdminMember(update.OldChatMember) || isAdminMember(update.NewChatMember)) {
+				c.Invalidate(update.Chat.ID)
+			}
+
+			return next(ctx, bot, update, data)
+		}
+	}
+
+	router.OuterMiddlewareChatMember(invalidate)
+	router.OuterMiddlewareMyChatMember(invalidate)
+	return router
+}
+
+// Passes if the message sender is a chat administrator or the owner.
+// Anonymous administrators (messages sent on behalf of the chat itself) pass too.
+//
+// The cache is invalidated on chat member updates only if it is registered with AdminCache.Register(),
+// otherwise changes of administrators are seen after TTL expires.
+func (c *AdminCache) IsAdmin() handlers.Filter[*goram.Message] {
+	return c.HasRights(nil)
+}
+
+// Passes if the message sender is an administrator and check returns true for their member.
+// The owner always passes. If check is nil, any administrator passes.
+//
+// Rights of an anonymous administrator are unknown, so an anonymous message passes
+// if check returns true for any anonymous administrator.
+//
+// Register the cache with AdminCache.Register() to see rights changes before TTL expires.
+func (c *AdminCache) HasRights(check func(member *goram.ChatMember) bool) handlers.Filter[*goram.Message] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		message *goram.Message,
+		data handlers.Data,
+	) (bool, error) {
+		if message.Chat == nil || message.Chat.Type == goram.ChatTypePrivate {
+			return false, nil
+		}
+
+		if message.SenderChat != nil {
+			if message.SenderChat.ID != message.Chat.ID {
+				return false, nil // Linked channel or another chat
+			}
+
+			// Only administrators can send messages on behalf of the chat
+			if check == nil {
+				return true, nil
+			}
+
+			return c.anyAnonymousAdmin(ctx, bot, message.Chat.ID, check)
+		}
+
+		if message.From == nil {
+			return false, nil
+		}
+
+		member, err := c.Administrator(ctx, bot, message.Chat.ID, message.From.ID)
+
+		if err != nil || member == nil {
+			return false, err
+		}
+
+		return member.Status == memberStatusCreator || check == nil || check(member), nil
+	}
+}
+
+// Passes if the bot is an administrator of the message chat and check returns true for its member.
+// If check is nil, the bot only has to be an administrator.
+//
+// Register the cache with AdminCache.Register() to see rights changes before TTL expires.
+func (c *AdminCache) BotHasRights(check func(member *goram.ChatMember) bool) handlers.Filter[*goram.Message] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		message *goram.Message,
+		data handlers.Data,
+	) (bool, error) {
+		if message.Chat == nil || message.Chat.Type == goram.ChatTypePrivate {
+			return false, nil
+		}
+
+		member, err := c.Administrator(ctx, bot, message.Chat.ID, bot.ID())
+
+		if err != nil || member == nil {
+			return false, err
+		}
+
+		return check == nil || check(member), nil
+	}
+}
+
+func (c *AdminCache) anyAnonymousAdmin(
+	ctx context.Context,
+	bot *goram.Bot,
+	chatID int64,
+	check func(member *goram.ChatMember) bool,
+) (bool, error) {
+	admins, err := c.Administrators(ctx, bot, chatID)
+
+	if err != nil {
+		return false, err
+	}
+
+	for i := range admins {
+		if admins[i].IsAnonymous && (admins[i].Status == memberStatusCreator || check(&admins[i])) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// See AdminCache.IsAdmin(). Uses filters.DefaultAdminCache,
+// call filters.DefaultAdminCache.Register(router) to invalidate it on chat member updates.
+func IsAdmin(
+	ctx context.Context,
+	bot *goram.Bot,
+	message *goram.Message,
+	data handlers.Data,
+) (bool, error) {
+	return DefaultAdminCache.IsAdmin()(ctx, bot, message, data)
+}
+
+// See AdminCache.HasRights(). Uses filters.DefaultAdminCache, see filters.IsAdmin.
+func HasRights(check func(member *goram.ChatMember) bool) handlers.Filter[*goram.Message] {
+	return DefaultAdminCache.HasRights(check)
+}
+
+// See AdminCache.BotHasRights(). Uses filters.DefaultAdminCache, see filters.IsAdmin.
+func BotHasRights(check func(member *goram.ChatMember) bool) handlers.Filter[*goram.Message] {
+	return DefaultAdminCache.BotHasRights(check)
+}
+
+func isAdminMember(member *goram.ChatMember) bool {
+	return member != nil && (member.Status == memberStatusCreator || member.Status == memberStatusAdministrator)
+}
@@ -0,0 +1,221 @@
+package filters
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+var ErrNotEnoughArgs = errors.New("not enough arguments")
+
+// Command arguments do not match the struct passed to filters.BindArgs().
+// Error() returns a message that can be sent to the user as is.
+type UsageError struct {
+	Usage string // Like "/ban <user> [days] <reason...>"
+	Arg   string // Name of the invalid argument. Empty if there are too few or too many arguments
+	Err   error
+}
+
+func (u *UsageError) Error() string {
+	if u.Arg == "" {
+		return u.Err.Error() + "\nusage: " + u.Usage
+	}
+
+	return "invalid " + u.Arg + ": " + u.Err.Error() + "\nusage: " + u.Usage
+}
+
+func (u *UsageError) Unwrap() error {
+	return u.Err
+}
+
+// Decodes command arguments into a struct pointed by dst.
+//
+// Fields are filled with arguments in order. Field options are set with the "arg" tag:
+//
+//	type BanArgs struct {
+//		User   string `arg:"user"`
+//		Days   int    `arg:"days,optional"`
+//		Reason string `arg:"reason,rest"`
+//	}
+//
+// "optional" fields may be missing, "rest" field gets the rest of ParsedCommand.RawArgs as is,
+// with original quotes and whitespace. Arguments go to required fields first, optional fields only get
+// arguments left over. If there is a "rest" field, an argument that can't be parsed into an optional field
+// is left for the next fields, so "/ban bob spammer" gives empty Days and "spammer" Reason.
+// Fields without a tag are named after the field, fields tagged with `arg:"-"` are skipped.
+// Supported field kinds are strings, bools, integers and floats.
+//
+// Returns *filters.UsageError if arguments do not match. Panics if dst is not a pointer to a struct.
+func BindArgs(command *ParsedCommand, dst any) error {
+	v := reflect.ValueOf(dst).Elem()
+	fields := argFields(v.Type())
+	usage := commandUsage(command, fields)
+	args := command.Args
+	rawArgs, starts, _ := splitArgs(command.RawArgs)
+
+	// command could be built by hand, then RawArgs can't be used for "rest" fields
+	if !slices.Equal(rawArgs, args) {
+		starts = nil
+	}
+
+	// optional fields only get arguments that are not needed by required fields
+	spare, hasRest := len(args), false
+
+	for _, f := range fields {
+		if f.rest {
+			hasRest = true
+		} else if !f.optional {
+			spare--
+		}
+	}
+
+	for _, f := range fields {
+		if len(args) == 0 {
+			if f.optional || f.rest {
+				continue
+			}
+
+			return &UsageError{Usage: usage, Err: ErrNotEnoughArgs}
+		}
+
+		if f.optional && !f.rest {
+			// an argument that does not fit the optional field is left for the rest field
+			if spare <= 0 || hasRest && !fitsArg(v.Field(f.index).Type(), args[0]) {
+				continue
+			}
+
+			spare--
+		}
+
+		pos := len(command.Args) - len(args)
+		arg := args[0]
+		args = args[1:]
+
+		if f.rest {
+			if starts != nil {
+				arg = command.RawArgs[starts[pos]:]
+			} else {
+				arg = strings.Join(append([]string{arg}, args...), " ")
+			}
+
+			args = nil
+		}
+
+		if err := setArg(v.Field(f.index), arg); err != nil {
+			return &UsageError{Usage: usage, Arg: f.name, Err: err}
+		}
+	}
+
+	if len(args) > 0 {
+		return &UsageError{Usage: usage, Err: errors.New("too many arguments")}
+	}
+
+	return nil
+}
+
+type argField struct {
+	index    int
+	name     string
+	optional bool
+	rest     bool
+}
+
+func argFields(t reflect.Type) []argField {
+	fields := []argField{}
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("arg")
+
+		if tag == "-" || !field.IsExported() {
+			continue
+		}
+
+		name, options, _ := strings.Cut(tag, ",")
+
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		fields = append(fields, argField{
+			index:    i,
+			name:     name,
+			optional: strings.Contains(options, "optional"),
+			rest:     strings.Contains(options, "rest"),
+		})
+	}
+
+	return fields
+}
+
+func commandUsage(command *ParsedCommand, fields []argField) string {
+	usage := strings.Builder{}
+	usage.WriteString(command.Prefix + command.Name)
+
+	for _, f := range fields {
+		name := f.name
+
+		if f.rest {
+			name += "..."
+		}
+
+		if f.optional {
+			usage.WriteString(" [" + name + "]")
+		} else {
+			usage.WriteString(" <" + name + ">")
+		}
+	}
+
+	return usage.String()
+}
+
+// Reports whether arg can be parsed into a value of type t.
+func fitsArg(t reflect.Type, arg string) bool {
+	return setArg(reflect.New(t).Elem(), arg) == nil
+}
+
+func setArg(v reflect.Value, arg string) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(arg)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(arg)
+
+		if err != nil {
+			return errors.New("expected true or false")
+		}
+
+		v.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(arg, 10, v.Type().Bits())
+
+		if err != nil {
+			return errors.New("expected an integer")
+		}
+
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		u, err := strconv.ParseUint(arg, 10, v.Type().Bits())
+
+		if err != nil {
+			return errors.New("expected a positive integer")
+		}
+
+		v.SetUint(u)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(arg, v.Type().Bits())
+
+		if err != nil {
+			return errors.New("expected a number")
+		}
+
+		v.SetFloat(f)
+	default:
+		return fmt.Errorf("unsupported argument type %s", v.Type())
+	}
+
+	return nil
+}
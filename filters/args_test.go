@@ -0,0 +1,107 @@
+package filters
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+type banArgs struct {
+	User   string `arg:"user"`
+	Days   int    `arg:"days,optional"`
+	Reason string `arg:"reason,rest"`
+}
+
+type muteArgs struct {
+	User    string `arg:"user"`
+	Minutes int    `arg:"minutes,optional"`
+	Chat    string `arg:"chat"`
+}
+
+func TestBindArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		dst  any
+		want any   // nil if an error is expected
+		err  error // Expected *UsageError.Err, nil for any
+	}{
+		{
+			name: "optional and rest",
+			text: "/ban bob 3 spam",
+			dst:  &banArgs{},
+			want: &banArgs{User: "bob", Days: 3, Reason: "spam"},
+		},
+		{
+			name: "optional does not take rest words",
+			text: "/ban bob he said hi",
+			dst:  &banArgs{},
+			want: &banArgs{User: "bob", Reason: "he said hi"},
+		},
+		{
+			name: "rest keeps quotes and whitespace",
+			text: `/ban bob 3 he said "hi  there"`,
+			dst:  &banArgs{},
+			want: &banArgs{User: "bob", Days: 3, Reason: `he said "hi  there"`},
+		},
+		{
+			name: "only required",
+			text: "/ban bob",
+			dst:  &banArgs{},
+			want: &banArgs{User: "bob"},
+		},
+		{
+			name: "required fields first",
+			text: "/mute bob chat",
+			dst:  &muteArgs{},
+			want: &muteArgs{User: "bob", Chat: "chat"},
+		},
+		{
+			name: "optional with spare argument",
+			text: "/mute bob 10 chat",
+			dst:  &muteArgs{},
+			want: &muteArgs{User: "bob", Minutes: 10, Chat: "chat"},
+		},
+		{
+			name: "invalid optional without rest",
+			text: "/mute bob x chat",
+			dst:  &muteArgs{},
+		},
+		{
+			name: "not enough arguments",
+			text: "/mute bob",
+			dst:  &muteArgs{},
+			err:  ErrNotEnoughArgs,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			command, ok := ParseCommand(tt.text)
+
+			if !ok {
+				t.Fatalf("ParseCommand(%q) failed", tt.text)
+			}
+
+			err := BindArgs(command, tt.dst)
+
+			if tt.want == nil {
+				var usageError *UsageError
+
+				if !errors.As(err, &usageError) || tt.err != nil && !errors.Is(err, tt.err) {
+					t.Fatalf("BindArgs() error = %v, want usage error %v", err, tt.err)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("BindArgs() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(tt.dst, tt.want) {
+				t.Errorf("BindArgs() = %+v, want %+v", tt.dst, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,235 @@
+package filters
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/TrixiS/goram"
+	"github.com/TrixiS/goram/handlers"
+)
+
+// handlers.Data key for parsed command. See filters.GetCommand().
+var CommandKey = handlers.NewKey[*ParsedCommand]("command")
+
+var ErrUnclosedQuote = errors.New("unclosed quote in command arguments")
+
+// Parsed bot command, like "/start@MyBot payload".
+type ParsedCommand struct {
+	Prefix  string   // "/" for bot commands
+	Name    string   // Command name without prefix and mention, as it was sent
+	Mention string   // Bot username after "@", if any
+	Args    []string // Arguments split by whitespace. Quoted arguments can contain whitespace
+	RawArgs string   // Text after the command
+}
+
+type CommandOptions struct {
+	Prefixes      []string // Optional. Default is "/". bot_command entities are used for "/" only
+	IgnoreCase    bool     // Optional. Match command names case-insensitive
+	IgnoreMention bool     // Optional. Do not check that the mention is the bot username
+}
+
+// Passes if the message is one of the commands. Names are without prefix: filters.Command("start", "help").
+//
+// Commands with a mention of another bot (/start@OtherBot) do not pass. Bot username is taken from Bot.Me().
+// Puts *filters.ParsedCommand to handler data, see filters.GetCommand().
+// Passes any command if names are empty.
+func Command(names ...string) handlers.Filter[*goram.Message] {
+	return CommandWithOptions(CommandOptions{}, names...)
+}
+
+// See filters.Command().
+func CommandWithOptions(options CommandOptions, names ...string) handlers.Filter[*goram.Message] {
+	if len(options.Prefixes) == 0 {
+		options.Prefixes = []string{"/"}
+	}
+
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		message *goram.Message,
+		data handlers.Data,
+	) (bool, error) {
+		command, ok := messageCommand(message, options.Prefixes)
+
+		if !ok || !matchName(command.Name, names, options.IgnoreCase) {
+			return false, nil
+		}
+
+		if command.Mention != "" && !options.IgnoreMention {
+			me, err := bot.Me(ctx)
+
+			if err != nil {
+				return false, err
+			}
+
+			if !strings.EqualFold(command.Mention, me.Username) {
+				return false, nil
+			}
+		}
+
+		CommandKey.Set(data, command)
+		return true, nil
+	}
+}
+
+// Returns command put to handler data by filters.Command() or nil.
+func GetCommand(data handlers.Data) *ParsedCommand {
+	command, _ := CommandKey.Get(data)
+	return command
+}
+
+// Parses a command from text, like "/start@MyBot payload". Returns false if text does not start with a prefix.
+// Unclosed quotes in arguments are ignored, see filters.SplitArgs().
+func ParseCommand(text string, prefixes ...string) (*ParsedCommand, bool) {
+	if len(prefixes) == 0 {
+		prefixes = []string{"/"}
+	}
+
+	token, rawArgs, _ := strings.Cut(text, " ")
+
+	if i := strings.IndexFunc(token, unicode.IsSpace); i >= 0 {
+		_, size := utf8.DecodeRuneInString(text[i:])
+		token, rawArgs = text[:i], text[i+size:]
+	}
+
+	for _, prefix := range prefixes {
+		if !strings.HasPrefix(token, prefix) || len(token) == len(prefix) {
+			continue
+		}
+
+		name, mention, _ := strings.Cut(token[len(prefix):], "@")
+		rawArgs = strings.TrimSpace(rawArgs)
+		args, _ := SplitArgs(rawArgs)
+
+		return &ParsedCommand{
+			Prefix:  prefix,
+			Name:    name,
+			Mention: mention,
+			Args:    args,
+			RawArgs: rawArgs,
+		}, true
+	}
+
+	return nil, false
+}
+
+// Splits command arguments by whitespace. Arguments in double or single quotes can contain whitespace,
+// backslash escapes the next character.
+//
+// Returns filters.ErrUnclosedQuote and arguments parsed so far if a quote is not closed.
+func SplitArgs(raw string) ([]string, error) {
+	args, _, err := splitArgs(raw)
+	return args, err
+}
+
+// Same as filters.SplitArgs(), but also returns byte offsets of the arguments in raw.
+func splitArgs(raw string) ([]string, []int, error) {
+	args, starts := []string{}, []int{}
+	current := strings.Builder{}
+	inArg, escaped := false, false
+	quote := rune(0)
+
+	startArg := func(i int) {
+		if !inArg {
+			starts = append(starts, i)
+			inArg = true
+		}
+	}
+
+	for i, r := range raw {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case r == '\\':
+			escaped = true
+			startArg(i)
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			startArg(i)
+		case unicode.IsSpace(r):
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			startArg(i)
+		}
+	}
+
+	if inArg {
+		args = append(args, current.String())
+	}
+
+	if quote != 0 {
+		return args, starts, ErrUnclosedQuote
+	}
+
+	return args, starts, nil
+}
+
+// Parses command from message text or caption. For "/" prefix the bot_command entity at the start is required.
+func messageCommand(message *goram.Message, prefixes []string) (*ParsedCommand, bool) {
+	text, entities := message.Text, message.Entities
+
+	if text == "" {
+		text, entities = message.Caption, message.CaptionEntities
+	}
+
+	command, ok := ParseCommand(text, prefixes...)
+
+	if !ok || command.Prefix != "/" {
+		return command, ok
+	}
+
+	for _, entity := range entities {
+		if entity.Type == goram.MessageEntityTypeBotCommand && entity.Offset == 0 {
+			token := utf16Prefix(text, entity.Length)
+			name, mention, _ := strings.Cut(strings.TrimPrefix(token, "/"), "@")
+			command.Name, command.Mention = name, mention
+			return command, true
+		}
+	}
+
+	return nil, false
+}
+
+// Returns the beginning of text that is length UTF-16 code units long.
+func utf16Prefix(text string, length int) string {
+	for i, r := range text {
+		if length <= 0 {
+			return text[:i]
+		}
+
+		if r >= 0x10000 && r <= utf8.MaxRune {
+			length -= 2
+		} else {
+			length--
+		}
+	}
+
+	return text
+}
+
+func matchName(name string, names []string, ignoreCase bool) bool {
+	if len(names) == 0 {
+		return true
+	}
+
+	for _, n := range names {
+		if n == name || (ignoreCase && strings.EqualFold(n, name)) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+package filters
+
+import (
+	"context"
+
+	"github.com/TrixiS/goram"
+	"github.com/TrixiS/goram/deeplink"
+	"github.com/TrixiS/goram/handlers"
+)
+
+// Passes if the message is "/start <payload>" and the payload was packed by deeplink.Pack() with the prefix.
+// Puts unpacked payload to handler data with deeplink.Key[T]() and the command with filters.CommandKey.
+// Handler data is not changed if the filter does not pass.
+func DeepLink[T any](prefix string) handlers.Filter[*goram.Message] {
+	return deepLinkFilter(func(payload string) (T, error) {
+		return deeplink.Unpack[T](prefix, payload)
+	})
+}
+
+// Does the same as filters.DeepLink() for payloads packed by deeplink.PackSigned().
+// Payloads with invalid signature do not pass.
+func SignedDeepLink[T any](key []byte, prefix string) handlers.Filter[*goram.Message] {
+	return deepLinkFilter(func(payload string) (T, error) {
+		return deeplink.UnpackSigned[T](key, prefix, payload)
+	})
+}
+
+func deepLinkFilter[T any](unpack func(payload string) (T, error)) handlers.Filter[*goram.Message] {
+	start := Command("start")
+
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		message *goram.Message,
+		data handlers.Data,
+	) (bool, error) {
+		// data is only changed if the payload is unpacked
+		commandData := handlers.Data{}
+		ok, err := start(ctx, bot, message, commandData)
+
+		if !ok || err != nil {
+			return false, err
+		}
+
+		command := GetCommand(commandData)
+
+		if command == nil || len(command.Args) != 1 {
+			return false, nil
+		}
+
+		value, err := unpack(command.Args[0])
+
+		// Payloads can be modified by users, so any unpack error means that the filter does not pass
+		if err != nil {
+			return false, nil
+		}
+
+		CommandKey.Set(data, command)
+		deeplink.Key[T]().Set(data, value)
+		return true, nil
+	}
+}
@@ -0,0 +1,234 @@
+package filters
+
+import (
+	"context"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/TrixiS/goram"
+	"github.com/TrixiS/goram/handlers"
+)
+
+// handlers.Data key for regexp submatches. See filters.Regexp().
+var RegexpKey = handlers.NewKey[[]string]("regexpMatch")
+
+func Not[T any](filter handlers.Filter[T]) handlers.Filter[T] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		update T,
+		data handlers.Data,
+	) (bool, error) {
+		ok, err := filter(ctx, bot, update, data)
+		return !ok && err == nil, err
+	}
+}
+
+// Passes if message text (or caption if there is no text) matches the regexp.
+// Puts submatches ([]string, see regexp.Regexp.FindStringSubmatch()) to handler data with filters.RegexpKey key.
+func Regexp(re *regexp.Regexp) handlers.Filter[*goram.Message] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		message *goram.Message,
+		data handlers.Data,
+	) (bool, error) {
+		return matchRegexp(re, messageText(message), data), nil
+	}
+}
+
+// Returns submatches put to handler data by filters.Regexp() or filters.CallbackRegexp(). Returns nil if there are none.
+func GetRegexpMatch(data handlers.Data) []string {
+	match, _ := RegexpKey.Get(data)
+	return match
+}
+
+// Passes if the message chat is one of the types.
+func ChatType(types ...goram.ChatType) handlers.Filter[*goram.Message] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		message *goram.Message,
+		data handlers.Data,
+	) (bool, error) {
+		return message.Chat != nil && slices.Contains(types, message.Chat.Type), nil
+	}
+}
+
+// Passes if the message content is one of the types. See goram.Message.ContentType().
+func ContentType(types ...goram.ContentType) handlers.Filter[*goram.Message] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		message *goram.Message,
+		data handlers.Data,
+	) (bool, error) {
+		return slices.Contains(types, message.ContentType()), nil
+	}
+}
+
+// Passes if the message is sent by one of the users.
+func FromUser(ids ...int64) handlers.Filter[*goram.Message] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		message *goram.Message,
+		data handlers.Data,
+	) (bool, error) {
+		return message.From != nil && slices.Contains(ids, message.From.ID), nil
+	}
+}
+
+// Passes if the message is sent to one of the threads (forum topics). Passes any thread if ids are empty.
+func InThread(ids ...int64) handlers.Filter[*goram.Message] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		message *goram.Message,
+		data handlers.Data,
+	) (bool, error) {
+		if message.MessageThreadID == 0 {
+			return false, nil
+		}
+
+		return len(ids) == 0 || slices.Contains(ids, message.MessageThreadID), nil
+	}
+}
+
+// Passes if the message text or caption has an entity of one of the types.
+func HasEntity(types ...goram.MessageEntityType) handlers.Filter[*goram.Message] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		message *goram.Message,
+		data handlers.Data,
+	) (bool, error) {
+		for _, entities := range [][]goram.MessageEntity{message.Entities, message.CaptionEntities} {
+			for _, entity := range entities {
+				if slices.Contains(types, entity.Type) {
+					return true, nil
+				}
+			}
+		}
+
+		return false, nil
+	}
+}
+
+func IsReply(
+	ctx context.Context,
+	bot *goram.Bot,
+	message *goram.Message,
+	data handlers.Data,
+) (bool, error) {
+	return message.ReplyToMessage != nil, nil
+}
+
+func IsForwarded(
+	ctx context.Context,
+	bot *goram.Bot,
+	message *goram.Message,
+	data handlers.Data,
+) (bool, error) {
+	return message.ForwardOrigin != nil, nil
+}
+
+// Passes if the message is sent via an inline bot.
+func ViaBot(
+	ctx context.Context,
+	bot *goram.Bot,
+	message *goram.Message,
+	data handlers.Data,
+) (bool, error) {
+	return message.ViaBot != nil, nil
+}
+
+// Passes if the message is sent to a forum topic.
+func IsTopicMessage(
+	ctx context.Context,
+	bot *goram.Bot,
+	message *goram.Message,
+	data handlers.Data,
+) (bool, error) {
+	return message.IsTopicMessage, nil
+}
+
+// Passes if callback query data equals to any of the provided strings.
+func CallbackData(datas ...string) handlers.Filter[*goram.CallbackQuery] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		query *goram.CallbackQuery,
+		data handlers.Data,
+	) (bool, error) {
+		return slices.Contains(datas, query.Data), nil
+	}
+}
+
+// Passes if callback query data starts with the prefix.
+func CallbackDataPrefix(prefix string) handlers.Filter[*goram.CallbackQuery] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		query *goram.CallbackQuery,
+		data handlers.Data,
+	) (bool, error) {
+		return strings.HasPrefix(query.Data, prefix), nil
+	}
+}
+
+// Passes if callback query data matches the regexp. Puts submatches to handler data, see filters.Regexp().
+func CallbackRegexp(re *regexp.Regexp) handlers.Filter[*goram.CallbackQuery] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		query *goram.CallbackQuery,
+		data handlers.Data,
+	) (bool, error) {
+		return matchRegexp(re, query.Data, data), nil
+	}
+}
+
+// Passes if callback query is sent by one of the users.
+func CallbackFromUser(ids ...int64) handlers.Filter[*goram.CallbackQuery] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		query *goram.CallbackQuery,
+		data handlers.Data,
+	) (bool, error) {
+		return query.From != nil && slices.Contains(ids, query.From.ID), nil
+	}
+}
+
+// Passes if callback query message chat is one of the types. Queries from inline messages do not pass.
+func CallbackChatType(types ...goram.ChatType) handlers.Filter[*goram.CallbackQuery] {
+	return func(
+		ctx context.Context,
+		bot *goram.Bot,
+		query *goram.CallbackQuery,
+		data handlers.Data,
+	) (bool, error) {
+		return query.Message != nil && query.Message.Chat != nil && slices.Contains(types, query.Message.Chat.Type), nil
+	}
+}
+
+func matchRegexp(re *regexp.Regexp, text string, data handlers.Data) bool {
+	match := re.FindStringSubmatch(text)
+
+	if match == nil {
+		return false
+	}
+
+	RegexpKey.Set(data, match)
+	return true
+}
+
+func messageText(message *goram.Message) string {
+	if message.Text == "" {
+		return message.Caption
+	}
+
+	return message.Text
+}
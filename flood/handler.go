@@ -8,7 +8,7 @@ import (
 
 type OnFloodFunc func(ctx context.Context, method string, request any, duration time.Duration)
 
-// Interface for flood handlers. See `flood.CondHandler` or `flood.SleepHandler`. Or write one yourself.
+// Interface for flood handlers. See `flood.Limiter`, `flood.CondHandler` or `flood.SleepHandler`. Or write one yourself.
 type Handler interface {
 	Enter(
 		ctx context.Context,
@@ -0,0 +1,279 @@
+package flood
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Amount of requests allowed per time period.
+type Rate struct {
+	Count int
+	Per   time.Duration
+	Burst int // Optional. Amount of requests that can be made at once. Default is 1
+}
+
+var (
+	DefaultGlobalRate  = Rate{Count: 30, Per: time.Second} // Broadcasting limit
+	DefaultPrivateRate = Rate{Count: 1, Per: time.Second}  // Limit for a single private chat
+	DefaultGroupRate   = Rate{Count: 20, Per: time.Minute} // Limit for a single group or channel
+)
+
+// Interval between chat bucket cleanups
+const limiterCleanupInterval = time.Minute
+
+type LimiterOptions struct {
+	GlobalRate  Rate                     // Optional. Default is flood.DefaultGlobalRate
+	PrivateRate Rate                     // Optional. Default is flood.DefaultPrivateRate
+	GroupRate   Rate                     // Optional. Default is flood.DefaultGroupRate
+	Limited     func(method string) bool // Optional. Returns true for methods that are rate limited. Default is flood.IsSendMethod
+	OnFlood     OnFloodFunc              // Optional. Gets called before sleeping on 429 error
+}
+
+// Proactive flood handler. Delays requests before they are made, so 429 errors should not happen at all.
+//
+// Uses token buckets with Telegram's documented limits: ~30 messages per second overall,
+// 1 message per second in a private chat and 20 messages per minute in a group.
+// Chat is taken from the ChatID field of the request, requests without it are limited only globally.
+// Requests with AllowPaidBroadcast set are not limited.
+//
+// If 429 error happens anyway, the limiter sleeps for the flood duration like flood.SleepHandler.
+type Limiter struct {
+	options LimiterOptions
+
+	mu          sync.Mutex
+	global      *bucket
+	chats       map[string]*bucket
+	lastCleanup time.Time
+}
+
+func NewLimiter(options LimiterOptions) *Limiter {
+	if options.GlobalRate.Count <= 0 {
+		options.GlobalRate = DefaultGlobalRate
+	}
+
+	if options.PrivateRate.Count <= 0 {
+		options.PrivateRate = DefaultPrivateRate
+	}
+
+	if options.GroupRate.Count <= 0 {
+		options.GroupRate = DefaultGroupRate
+	}
+
+	if options.Limited == nil {
+		options.Limited = IsSendMethod
+	}
+
+	now := time.Now()
+
+	return &Limiter{
+		options:     options,
+		global:      newBucket(options.GlobalRate, now),
+		chats:       make(map[string]*bucket),
+		lastCleanup: now,
+	}
+}
+
+// Waits until the request can be made without exceeding the limits or ctx is done.
+func (l *Limiter) Enter(ctx context.Context, method string, request any) {
+	if !l.options.Limited(method) {
+		return
+	}
+
+	chat, paid := requestChat(request)
+
+	if paid {
+		return
+	}
+
+	l.mu.Lock()
+	now := time.Now()
+	l.cleanup(now)
+
+	buckets := []*bucket{l.global}
+
+	if chat.key != "" {
+		chatBucket := l.chats[chat.key]
+
+		if chatBucket == nil {
+			rate := l.options.GroupRate
+
+			if chat.private {
+				rate = l.options.PrivateRate
+			}
+
+			chatBucket = newBucket(rate, now)
+			l.chats[chat.key] = chatBucket
+		}
+
+		buckets = append(buckets, chatBucket)
+	}
+
+	wait := time.Duration(0)
+
+	for _, b := range buckets {
+		if d := b.reserve(now); d > wait {
+			wait = d
+		}
+	}
+
+	l.mu.Unlock()
+
+	if wait <= 0 {
+		return
+	}
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		l.mu.Lock()
+
+		for _, b := range buckets {
+			b.tokens++
+		}
+
+		l.mu.Unlock()
+	}
+}
+
+func (l *Limiter) Handle(
+	ctx context.Context,
+	method string,
+	request any,
+	duration time.Duration,
+) {
+	if l.options.OnFlood != nil {
+		l.options.OnFlood(ctx, method, request, duration)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-time.After(duration):
+	}
+}
+
+// Removes chat buckets that are full, so the map does not grow with every chat the bot has ever sent to.
+func (l *Limiter) cleanup(now time.Time) {
+	if now.Sub(l.lastCleanup) < limiterCleanupInterval {
+		return
+	}
+
+	l.lastCleanup = now
+
+	for key, b := range l.chats {
+		if b.refill(now); b.tokens >= b.burst {
+			delete(l.chats, key)
+		}
+	}
+}
+
+// Returns true for methods that send messages: send*, forward* and copy*.
+func IsSendMethod(method string) bool {
+	return strings.HasPrefix(method, "send") ||
+		strings.HasPrefix(method, "forward") ||
+		strings.HasPrefix(method, "copy")
+}
+
+// Token bucket. Tokens can go negative, a negative amount means the time that callers already wait for.
+type bucket struct {
+	tokens   float64
+	burst    float64
+	interval time.Duration // Time to get one token
+	last     time.Time
+}
+
+func newBucket(rate Rate, now time.Time) *bucket {
+	burst := rate.Burst
+
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &bucket{
+		tokens:   float64(burst),
+		burst:    float64(burst),
+		interval: rate.Per / time.Duration(rate.Count),
+		last:     now,
+	}
+}
+
+func (b *bucket) refill(now time.Time) {
+	if b.interval > 0 {
+		b.tokens += float64(now.Sub(b.last)) / float64(b.interval)
+	}
+
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+
+	b.last = now
+}
+
+// Takes a token and returns the time to wait before using it.
+func (b *bucket) reserve(now time.Time) time.Duration {
+	b.refill(now)
+	b.tokens--
+
+	if b.tokens >= 0 {
+		return 0
+	}
+
+	return time.Duration(-b.tokens * float64(b.interval))
+}
+
+type chatInfo struct {
+	key     string // Empty if the request has no chat
+	private bool
+}
+
+// Gets chat from the ChatID field and AllowPaidBroadcast field of a request struct.
+// Reflection is used because this package can not depend on goram request types.
+func requestChat(request any) (chat chatInfo, paid bool) {
+	v := reflect.ValueOf(request)
+
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return chat, false
+		}
+
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return chat, false
+	}
+
+	if f := v.FieldByName("AllowPaidBroadcast"); f.IsValid() && f.Kind() == reflect.Bool {
+		paid = f.Bool()
+	}
+
+	f := v.FieldByName("ChatID")
+
+	switch {
+	case !f.IsValid():
+	case f.CanInt():
+		chat = chatFromID(f.Int())
+	case f.Kind() == reflect.Struct:
+		if id := f.FieldByName("ID"); id.IsValid() && id.CanInt() && id.Int() != 0 {
+			chat = chatFromID(id.Int())
+		} else if username := f.FieldByName("Username"); username.IsValid() && username.Kind() == reflect.String && username.String() != "" {
+			chat = chatInfo{key: "@" + username.String()}
+		}
+	}
+
+	return chat, paid
+}
+
+func chatFromID(id int64) chatInfo {
+	if id == 0 {
+		return chatInfo{}
+	}
+
+	return chatInfo{key: strconv.FormatInt(id, 10), private: id > 0}
+}
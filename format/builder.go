@@ -0,0 +1,105 @@
+package format
+
+import "github.com/TrixiS/goram"
+
+// Fluent builder of formatted text. Every method appends a node to the end.
+//
+// text, entities := format.NewBuilder().
+//
+//	Text("Hello, ").
+//	Bold("dear ", format.Italic("user")).
+//	Entities()
+type Builder struct {
+	nodes []Node
+}
+
+func NewBuilder() *Builder {
+	return &Builder{}
+}
+
+// Appends nodes as is.
+func (b *Builder) Add(nodes ...Node) *Builder {
+	b.nodes = append(b.nodes, nodes...)
+	return b
+}
+
+func (b *Builder) Text(text string) *Builder {
+	return b.Add(Text(text))
+}
+
+// Appends a new line.
+func (b *Builder) Line() *Builder {
+	return b.Add(Text("\n"))
+}
+
+func (b *Builder) Bold(parts ...any) *Builder {
+	return b.Add(Bold(parts...))
+}
+
+func (b *Builder) Italic(parts ...any) *Builder {
+	return b.Add(Italic(parts...))
+}
+
+func (b *Builder) Underline(parts ...any) *Builder {
+	return b.Add(Underline(parts...))
+}
+
+func (b *Builder) Strikethrough(parts ...any) *Builder {
+	return b.Add(Strikethrough(parts...))
+}
+
+func (b *Builder) Spoiler(parts ...any) *Builder {
+	return b.Add(Spoiler(parts...))
+}
+
+func (b *Builder) Blockquote(parts ...any) *Builder {
+	return b.Add(Blockquote(parts...))
+}
+
+func (b *Builder) ExpandableBlockquote(parts ...any) *Builder {
+	return b.Add(ExpandableBlockquote(parts...))
+}
+
+func (b *Builder) Code(text string) *Builder {
+	return b.Add(Code(text))
+}
+
+func (b *Builder) Pre(language string, text string) *Builder {
+	return b.Add(Pre(language, text))
+}
+
+func (b *Builder) TextLink(url string, parts ...any) *Builder {
+	return b.Add(TextLink(url, parts...))
+}
+
+func (b *Builder) TextMention(user *goram.User, parts ...any) *Builder {
+	return b.Add(TextMention(user, parts...))
+}
+
+func (b *Builder) CustomEmoji(emojiID string, emoji string) *Builder {
+	return b.Add(CustomEmoji(emojiID, emoji))
+}
+
+func (b *Builder) DateTime(unixTime int, format string, text string) *Builder {
+	return b.Add(DateTime(unixTime, format, text))
+}
+
+// Returns all appended nodes as a single node.
+func (b *Builder) Build() Node {
+	return Node{Children: b.nodes}
+}
+
+// See Node.Entities().
+func (b *Builder) Entities() (string, []goram.MessageEntity) {
+	return b.Build().Entities()
+}
+
+// See Node.HTML().
+func (b *Builder) HTML() string {
+	return b.Build().HTML()
+}
+
+// See Node.MarkdownV2().
+func (b *Builder) MarkdownV2() string {
+	return b.Build().MarkdownV2()
+}
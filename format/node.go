@@ -0,0 +1,124 @@
+package format
+
+import (
+	"fmt"
+
+	"github.com/TrixiS/goram"
+)
+
+// Formatted text tree. A node is either plain text or an entity with child nodes.
+//
+// Node can be rendered to text with entities (Node.Entities()), HTML (Node.HTML()) or MarkdownV2 (Node.MarkdownV2()).
+type Node struct {
+	Entity   *goram.MessageEntity // nil for plain text and groups. Offset and Length are ignored
+	Text     string               // Only for plain text nodes
+	Children []Node
+}
+
+// Plain text node.
+func Text(text string) Node {
+	return Node{Text: text}
+}
+
+// Node without entity that holds parts together.
+//
+// Parts can be strings, format.Node values or anything else, which is converted with fmt.Sprint.
+func Group(parts ...any) Node {
+	return Node{Children: nodes(parts)}
+}
+
+// Entity node with parts as children. See format.Group() for parts.
+func Entity(entity goram.MessageEntity, parts ...any) Node {
+	return Node{Entity: &entity, Children: nodes(parts)}
+}
+
+func Bold(parts ...any) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeBold}, parts...)
+}
+
+func Italic(parts ...any) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeItalic}, parts...)
+}
+
+func Underline(parts ...any) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeUnderline}, parts...)
+}
+
+func Strikethrough(parts ...any) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeStrikethrough}, parts...)
+}
+
+func Spoiler(parts ...any) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeSpoiler}, parts...)
+}
+
+func Blockquote(parts ...any) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeBlockquote}, parts...)
+}
+
+func ExpandableBlockquote(parts ...any) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeExpandableBlockquote}, parts...)
+}
+
+// Inline monowidth text. Nested entities are not allowed by Telegram.
+func Code(text string) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeCode}, text)
+}
+
+// Monowidth block. language is optional.
+func Pre(language string, text string) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypePre, Language: language}, text)
+}
+
+func TextLink(url string, parts ...any) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeTextLink, URL: url}, parts...)
+}
+
+// Mention of a user without username.
+func TextMention(user *goram.User, parts ...any) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeTextMention, User: user}, parts...)
+}
+
+// Custom emoji. emoji is a regular emoji shown where custom emojis are not supported.
+func CustomEmoji(emojiID string, emoji string) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeCustomEmoji, CustomEmojiID: emojiID}, emoji)
+}
+
+// Date and time shown in the user's time zone. text is shown where date_time entities are not supported.
+func DateTime(unixTime int, format string, text string) Node {
+	return Entity(goram.MessageEntity{Type: goram.MessageEntityTypeDateTime, UnixTime: unixTime, DateTimeFormat: format}, text)
+}
+
+// Returns text of the node without formatting.
+func (n Node) PlainText() string {
+	if len(n.Children) == 0 {
+		return n.Text
+	}
+
+	text := n.Text
+
+	for _, child := range n.Children {
+		text += child.PlainText()
+	}
+
+	return text
+}
+
+func nodes(parts []any) []Node {
+	result := make([]Node, 0, len(parts))
+
+	for _, part := range parts {
+		switch p := part.(type) {
+		case Node:
+			result = append(result, p)
+		case *Node:
+			result = append(result, *p)
+		case string:
+			result = append(result, Text(p))
+		default:
+			result = append(result, Text(fmt.Sprint(p)))
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,176 @@
+package format
+
+import (
+	"sort"
+
+	"github.com/TrixiS/goram"
+)
+
+// Piece of text with all entities that cover it.
+type Segment struct {
+	Text     string
+	Entities []goram.MessageEntity // Entities in order of their offsets. Offset and Length are of the whole entity
+}
+
+// Splits text into segments at every entity boundary. Offsets of entities are in UTF-16 code units.
+// Entities out of text bounds are clamped.
+func Segments(text string, entities []goram.MessageEntity) []Segment {
+	units := utf16Index(text)
+	total := len(units) - 1
+	sorted := sortEntities(entities)
+	boundaries := []int{0, total}
+
+	for _, e := range sorted {
+		start, end := entityBounds(e, total)
+		boundaries = append(boundaries, start, end)
+	}
+
+	sort.Ints(boundaries)
+	segments := []Segment{}
+
+	for i := 1; i < len(boundaries); i++ {
+		start, end := boundaries[i-1], boundaries[i]
+
+		if start == end || units[start] == units[end] {
+			continue
+		}
+
+		segment := Segment{Text: text[units[start]:units[end]]}
+
+		for _, e := range sorted {
+			if eStart, eEnd := entityBounds(e, total); eStart <= start && end <= eEnd {
+				segment.Entities = append(segment.Entities, e)
+			}
+		}
+
+		segments = append(segments, segment)
+	}
+
+	return segments
+}
+
+// Builds a node tree from text and entities (for example, Message.Text and Message.Entities).
+//
+// Nested entities become child nodes. Partially overlapping entities are split,
+// so that the entity that starts first stays whole. Of entities that start together the longest one stays whole.
+func Parse(text string, entities []goram.MessageEntity) Node {
+	return Node{Children: buildNodes(Segments(text, entities))}
+}
+
+// Parses Message.Text and Message.Entities or Message.Caption and Message.CaptionEntities if there is no text.
+func ParseMessage(message *goram.Message) Node {
+	if message.Text == "" && message.Caption != "" {
+		return Parse(message.Caption, message.CaptionEntities)
+	}
+
+	return Parse(message.Text, message.Entities)
+}
+
+func buildNodes(segments []Segment) []Node {
+	nodes := []Node{}
+
+	for i := 0; i < len(segments); {
+		if len(segments[i].Entities) == 0 {
+			nodes = append(nodes, Text(segments[i].Text))
+			i++
+			continue
+		}
+
+		entity, end := longestEntity(segments, i)
+		children := make([]Segment, 0, end-i)
+
+		for _, s := range segments[i:end] {
+			children = append(children, Segment{Text: s.Text, Entities: withoutEntity(s.Entities, entity)})
+		}
+
+		e := entity
+		e.Offset, e.Length = 0, 0
+		nodes = append(nodes, Node{Entity: &e, Children: buildNodes(children)})
+		i = end
+	}
+
+	return nodes
+}
+
+// Returns the entity of segments[i] that covers the most consecutive segments and the index of the first segment after it.
+func longestEntity(segments []Segment, i int) (goram.MessageEntity, int) {
+	best, bestEnd := segments[i].Entities[0], i+1
+
+	for _, e := range segments[i].Entities {
+		end := i + 1
+
+		for end < len(segments) && hasEntity(segments[end].Entities, e) {
+			end++
+		}
+
+		if end > bestEnd {
+			best, bestEnd = e, end
+		}
+	}
+
+	return best, bestEnd
+}
+
+func hasEntity(entities []goram.MessageEntity, entity goram.MessageEntity) bool {
+	for _, e := range entities {
+		if sameEntity(e, entity) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func withoutEntity(entities []goram.MessageEntity, entity goram.MessageEntity) []goram.MessageEntity {
+	result := make([]goram.MessageEntity, 0, len(entities))
+
+	for _, e := range entities {
+		if !sameEntity(e, entity) {
+			result = append(result, e)
+		}
+	}
+
+	return result
+}
+
+func sameEntity(a goram.MessageEntity, b goram.MessageEntity) bool {
+	return a.Type == b.Type && a.Offset == b.Offset && a.Length == b.Length && a.URL == b.URL &&
+		a.Language == b.Language && a.CustomEmojiID == b.CustomEmojiID && a.User == b.User &&
+		a.UnixTime == b.UnixTime && a.DateTimeFormat == b.DateTimeFormat
+}
+
+// Sorts entities by offset, longer entities go first.
+func sortEntities(entities []goram.MessageEntity) []goram.MessageEntity {
+	sorted := make([]goram.MessageEntity, len(entities))
+	copy(sorted, entities)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Offset != sorted[j].Offset {
+			return sorted[i].Offset < sorted[j].Offset
+		}
+
+		return sorted[i].Length > sorted[j].Length
+	})
+
+	return sorted
+}
+
+func entityBounds(e goram.MessageEntity, total int) (int, int) {
+	start := min(max(int(e.Offset), 0), total)
+	end := min(max(start+e.Length, start), total)
+	return start, end
+}
+
+// Returns byte index in text for every UTF-16 code unit index, including the end of text.
+// The second unit of a surrogate pair points to the start of its rune.
+func utf16Index(text string) []int {
+	index := make([]int, 0, len(text)+1)
+
+	for i, r := range text {
+		for n := utf16RuneLen(r); n > 0; n-- {
+			index = append(index, i)
+		}
+	}
+
+	return append(index, len(text))
+}
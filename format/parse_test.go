@@ -0,0 +1,128 @@
+package format
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TrixiS/goram"
+)
+
+func TestSegments(t *testing.T) {
+	italic := goram.MessageEntity{Type: goram.MessageEntityTypeItalic, Offset: 1, Length: 2}
+	bold := goram.MessageEntity{Type: goram.MessageEntityTypeBold, Offset: 1, Length: 10}
+
+	tests := []struct {
+		name     string
+		text     string
+		entities []goram.MessageEntity
+		want     []Segment
+	}{
+		{
+			name:     "surrogate pair",
+			text:     "a😀b",
+			entities: []goram.MessageEntity{italic},
+			want:     []Segment{{Text: "a"}, {Text: "😀", Entities: []goram.MessageEntity{italic}}, {Text: "b"}},
+		},
+		{
+			name:     "entity out of bounds",
+			text:     "ab",
+			entities: []goram.MessageEntity{bold},
+			want:     []Segment{{Text: "a"}, {Text: "b", Entities: []goram.MessageEntity{bold}}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Segments(tt.text, tt.entities); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Segments() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		entities []goram.MessageEntity
+		want     []goram.MessageEntity // entities of the parsed node
+	}{
+		{
+			name: "emoji before entity",
+			text: "😀ab",
+			entities: []goram.MessageEntity{
+				{Type: goram.MessageEntityTypeBold, Offset: 2, Length: 2},
+			},
+			want: []goram.MessageEntity{
+				{Type: goram.MessageEntityTypeBold, Offset: 2, Length: 2},
+			},
+		},
+		{
+			name: "nested entities",
+			text: "a😀bc",
+			entities: []goram.MessageEntity{
+				{Type: goram.MessageEntityTypeItalic, Offset: 1, Length: 2},
+				{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 5},
+			},
+			want: []goram.MessageEntity{
+				{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 5},
+				{Type: goram.MessageEntityTypeItalic, Offset: 1, Length: 2},
+			},
+		},
+		{
+			name: "overlapping entities",
+			text: "abcd",
+			entities: []goram.MessageEntity{
+				{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 3},
+				{Type: goram.MessageEntityTypeItalic, Offset: 2, Length: 2},
+			},
+			want: []goram.MessageEntity{
+				{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 3},
+				{Type: goram.MessageEntityTypeItalic, Offset: 2, Length: 1},
+				{Type: goram.MessageEntityTypeItalic, Offset: 3, Length: 1},
+			},
+		},
+		{
+			name: "earlier entity stays whole",
+			text: "abcd",
+			entities: []goram.MessageEntity{
+				{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 2},
+				{Type: goram.MessageEntityTypeItalic, Offset: 1, Length: 3},
+			},
+			want: []goram.MessageEntity{
+				{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 2},
+				{Type: goram.MessageEntityTypeItalic, Offset: 1, Length: 1},
+				{Type: goram.MessageEntityTypeItalic, Offset: 2, Length: 2},
+			},
+		},
+		{
+			name: "longer entity stays whole",
+			text: "abcd",
+			entities: []goram.MessageEntity{
+				{Type: goram.MessageEntityTypeItalic, Offset: 0, Length: 2},
+				{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 4},
+				{Type: goram.MessageEntityTypeCode, Offset: 1, Length: 3},
+			},
+			want: []goram.MessageEntity{
+				{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 4},
+				{Type: goram.MessageEntityTypeItalic, Offset: 0, Length: 2},
+				{Type: goram.MessageEntityTypeCode, Offset: 1, Length: 1},
+				{Type: goram.MessageEntityTypeCode, Offset: 2, Length: 2},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, entities := Parse(tt.text, tt.entities).Entities()
+
+			if text != tt.text {
+				t.Errorf("text = %q, want %q", text, tt.text)
+			}
+
+			if !equalEntities(entities, tt.want) {
+				t.Errorf("entities = %+v, want %+v", entities, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,273 @@
+package format
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/TrixiS/goram"
+)
+
+var (
+	htmlReplacer       = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+	markdownV2Replacer = strings.NewReplacer(
+		`\`, `\\`, "_", `\_`, "*", `\*`, "[", `\[`, "]", `\]`, "(", `\(`, ")", `\)`, "~", `\~`, "`", "\\`",
+		">", `\>`, "#", `\#`, "+", `\+`, "-", `\-`, "=", `\=`, "|", `\|`, "{", `\{`, "}", `\}`, ".", `\.`, "!", `\!`,
+	)
+	markdownV2CodeReplacer = strings.NewReplacer(`\`, `\\`, "`", "\\`")
+	markdownV2LinkReplacer = strings.NewReplacer(`\`, `\\`, ")", `\)`)
+)
+
+// Escapes text for goram.ParseModeHTML.
+func EscapeHTML(text string) string {
+	return htmlReplacer.Replace(text)
+}
+
+// Escapes text for goram.ParseModeMarkdownV2.
+func EscapeMarkdownV2(text string) string {
+	return markdownV2Replacer.Replace(text)
+}
+
+// Returns plain text and entities with offsets and lengths in UTF-16 code units.
+// Entities of empty nodes are omitted.
+func (n Node) Entities() (string, []goram.MessageEntity) {
+	r := entityRenderer{}
+	r.render(n)
+	return r.text.String(), r.entities
+}
+
+type entityRenderer struct {
+	text     strings.Builder
+	offset   int64
+	entities []goram.MessageEntity
+}
+
+func (r *entityRenderer) render(n Node) {
+	r.text.WriteString(n.Text)
+	r.offset += int64(UTF16Len(n.Text))
+
+	if n.Entity == nil {
+		for _, child := range n.Children {
+			r.render(child)
+		}
+
+		return
+	}
+
+	i, start := len(r.entities), r.offset
+	r.entities = append(r.entities, *n.Entity)
+
+	for _, child := range n.Children {
+		r.render(child)
+	}
+
+	if r.offset == start {
+		r.entities = r.entities[:i]
+		return
+	}
+
+	r.entities[i].Offset = start
+	r.entities[i].Length = int(r.offset - start)
+}
+
+// Returns text for goram.ParseModeHTML. Empty entities are omitted.
+func (n Node) HTML() string {
+	b := strings.Builder{}
+	writeHTML(&b, n)
+	return b.String()
+}
+
+func writeHTML(b *strings.Builder, n Node) {
+	if n.Entity != nil && n.PlainText() == "" {
+		return
+	}
+
+	open, close := htmlTags(n.Entity)
+	b.WriteString(open)
+	b.WriteString(EscapeHTML(n.Text))
+
+	for _, child := range n.Children {
+		writeHTML(b, child)
+	}
+
+	b.WriteString(close)
+}
+
+func htmlTags(entity *goram.MessageEntity) (string, string) {
+	if entity == nil {
+		return "", ""
+	}
+
+	switch entity.Type {
+	case goram.MessageEntityTypeBold:
+		return "<b>", "</b>"
+	case goram.MessageEntityTypeItalic:
+		return "<i>", "</i>"
+	case goram.MessageEntityTypeUnderline:
+		return "<u>", "</u>"
+	case goram.MessageEntityTypeStrikethrough:
+		return "<s>", "</s>"
+	case goram.MessageEntityTypeSpoiler:
+		return "<tg-spoiler>", "</tg-spoiler>"
+	case goram.MessageEntityTypeBlockquote:
+		return "<blockquote>", "</blockquote>"
+	case goram.MessageEntityTypeExpandableBlockquote:
+		return "<blockquote expandable>", "</blockquote>"
+	case goram.MessageEntityTypeCode:
+		return "<code>", "</code>"
+	case goram.MessageEntityTypePre:
+		if entity.Language == "" {
+			return "<pre>", "</pre>"
+		}
+
+		return `<pre><code class="language-` + EscapeHTML(entity.Language) + `">`, "</code></pre>"
+	case goram.MessageEntityTypeTextLink:
+		return `<a href="` + EscapeHTML(entity.URL) + `">`, "</a>"
+	case goram.MessageEntityTypeTextMention:
+		if entity.User == nil {
+			return "", ""
+		}
+
+		return `<a href="tg://user?id=` + strconv.FormatInt(entity.User.ID, 10) + `">`, "</a>"
+	case goram.MessageEntityTypeCustomEmoji:
+		return `<tg-emoji emoji-id="` + EscapeHTML(entity.CustomEmojiID) + `">`, "</tg-emoji>"
+	case goram.MessageEntityTypeDateTime:
+		open := `<tg-time unix="` + strconv.Itoa(entity.UnixTime) + `"`
+
+		if entity.DateTimeFormat != "" {
+			open += ` format="` + EscapeHTML(entity.DateTimeFormat) + `"`
+		}
+
+		return open + ">", "</tg-time>"
+	case goram.MessageEntityTypeMention,
+		goram.MessageEntityTypeHashtag,
+		goram.MessageEntityTypeCashtag,
+		goram.MessageEntityTypeBotCommand,
+		goram.MessageEntityTypeURL,
+		goram.MessageEntityTypeEmail,
+		goram.MessageEntityTypePhoneNumber:
+		// Detected by Telegram automatically
+	}
+
+	return "", ""
+}
+
+// Returns text for goram.ParseModeMarkdownV2. Empty entities are omitted.
+func (n Node) MarkdownV2() string {
+	r := markdownRenderer{underscoreEnd: -1}
+	r.render(n, EscapeMarkdownV2)
+	return r.b.String()
+}
+
+type markdownRenderer struct {
+	b             strings.Builder
+	underscoreEnd int // Position right after the last written "_" or "__" marker
+}
+
+func (r *markdownRenderer) render(n Node, escape func(string) string) {
+	if n.Entity == nil {
+		r.text(n, escape)
+		return
+	}
+
+	if n.PlainText() == "" {
+		return
+	}
+
+	switch n.Entity.Type {
+	case goram.MessageEntityTypeBold:
+		r.wrap(n, "*", "*")
+	case goram.MessageEntityTypeItalic:
+		r.underscore("_")
+		r.text(n, EscapeMarkdownV2)
+		r.underscore("_")
+	case goram.MessageEntityTypeUnderline:
+		r.underscore("__")
+		r.text(n, EscapeMarkdownV2)
+		r.underscore("__")
+	case goram.MessageEntityTypeStrikethrough:
+		r.wrap(n, "~", "~")
+	case goram.MessageEntityTypeSpoiler:
+		r.wrap(n, "||", "||")
+	case goram.MessageEntityTypeCode:
+		r.b.WriteString("`")
+		r.text(n, markdownV2CodeReplacer.Replace)
+		r.b.WriteString("`")
+	case goram.MessageEntityTypePre:
+		r.b.WriteString("```" + n.Entity.Language + "\n")
+		r.text(n, markdownV2CodeReplacer.Replace)
+		r.b.WriteString("```")
+	case goram.MessageEntityTypeTextLink:
+		r.wrap(n, "[", "]("+markdownV2LinkReplacer.Replace(n.Entity.URL)+")")
+	case goram.MessageEntityTypeTextMention:
+		if n.Entity.User == nil {
+			r.text(n, escape)
+			return
+		}
+
+		r.wrap(n, "[", "](tg://user?id="+strconv.FormatInt(n.Entity.User.ID, 10)+")")
+	case goram.MessageEntityTypeCustomEmoji:
+		r.wrap(n, "![", "](tg://emoji?id="+markdownV2LinkReplacer.Replace(n.Entity.CustomEmojiID)+")")
+	case goram.MessageEntityTypeDateTime:
+		url := "tg://time?unix=" + strconv.Itoa(n.Entity.UnixTime)
+
+		if n.Entity.DateTimeFormat != "" {
+			url += "&format=" + n.Entity.DateTimeFormat
+		}
+
+		r.wrap(n, "![", "]("+markdownV2LinkReplacer.Replace(url)+")")
+	case goram.MessageEntityTypeBlockquote, goram.MessageEntityTypeExpandableBlockquote:
+		r.blockquote(n)
+	default:
+		// mention, hashtag, url, etc. are detected by Telegram automatically
+		r.text(n, escape)
+	}
+}
+
+func (r *markdownRenderer) text(n Node, escape func(string) string) {
+	r.b.WriteString(escape(n.Text))
+
+	for _, child := range n.Children {
+		r.render(child, escape)
+	}
+}
+
+func (r *markdownRenderer) wrap(n Node, open string, close string) {
+	r.b.WriteString(open)
+	r.text(n, EscapeMarkdownV2)
+	r.b.WriteString(close)
+}
+
+// Writes italic or underline marker.
+// Adjacent markers are separated with \r, because "___" is ambiguous for Telegram.
+func (r *markdownRenderer) underscore(marker string) {
+	if r.b.Len() == r.underscoreEnd {
+		r.b.WriteString("\r")
+	}
+
+	r.b.WriteString(marker)
+	r.underscoreEnd = r.b.Len()
+}
+
+// Every line of a block quotation must start with ">".
+func (r *markdownRenderer) blockquote(n Node) {
+	inner := markdownRenderer{underscoreEnd: -1}
+	inner.text(n, EscapeMarkdownV2)
+	lines := strings.Split(inner.b.String(), "\n")
+	expandable := n.Entity.Type == goram.MessageEntityTypeExpandableBlockquote
+
+	for i, line := range lines {
+		if i > 0 {
+			r.b.WriteString("\n")
+		}
+
+		if i == 0 && expandable {
+			r.b.WriteString("**")
+		}
+
+		r.b.WriteString(">" + line)
+	}
+
+	if expandable {
+		r.b.WriteString("||")
+	}
+}
@@ -0,0 +1,104 @@
+package format
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TrixiS/goram"
+)
+
+func TestEntities(t *testing.T) {
+	tests := []struct {
+		name     string
+		node     Node
+		text     string
+		entities []goram.MessageEntity
+	}{
+		{
+			name:     "emoji before entity",
+			node:     Group("😀 ", Bold("hi")),
+			text:     "😀 hi",
+			entities: []goram.MessageEntity{{Type: goram.MessageEntityTypeBold, Offset: 3, Length: 2}},
+		},
+		{
+			name:     "surrogate pairs inside entity",
+			node:     Group("a", Italic("👍🏽x")),
+			text:     "a👍🏽x",
+			entities: []goram.MessageEntity{{Type: goram.MessageEntityTypeItalic, Offset: 1, Length: 5}},
+		},
+		{
+			name: "nested entities",
+			node: Bold("a", Italic("é😀")),
+			text: "aé😀",
+			entities: []goram.MessageEntity{
+				{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 4},
+				{Type: goram.MessageEntityTypeItalic, Offset: 1, Length: 3},
+			},
+		},
+		{
+			name: "empty entity",
+			node: Group("a", Bold(""), "b"),
+			text: "ab",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text, entities := tt.node.Entities()
+
+			if text != tt.text {
+				t.Errorf("text = %q, want %q", text, tt.text)
+			}
+
+			if !equalEntities(entities, tt.entities) {
+				t.Errorf("entities = %+v, want %+v", entities, tt.entities)
+			}
+		})
+	}
+}
+
+func TestHTML(t *testing.T) {
+	tests := []struct {
+		name string
+		node Node
+		want string
+	}{
+		{name: "escaping", node: Group("<a>", Bold("😀&")), want: "&lt;a&gt;<b>😀&amp;</b>"},
+		{name: "empty entity", node: Group("a", Italic()), want: "a"},
+		{name: "pre", node: Pre("go", "x < y"), want: `<pre><code class="language-go">x &lt; y</code></pre>`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.node.HTML(); got != tt.want {
+				t.Errorf("HTML() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownV2(t *testing.T) {
+	tests := []struct {
+		name string
+		node Node
+		want string
+	}{
+		{name: "escaping", node: Bold("1.5*2"), want: `*1\.5\*2*`},
+		{name: "adjacent italic and underline", node: Group(Italic("a"), Underline("b")), want: "_a_\r__b__"},
+		{name: "italic inside underline", node: Underline(Italic("a")), want: "__\r_a_\r__"},
+		{name: "code", node: Code("a`b"), want: "`a\\`b`"},
+		{name: "blockquote", node: Blockquote("a\nb"), want: ">a\n>b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.node.MarkdownV2(); got != tt.want {
+				t.Errorf("MarkdownV2() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func equalEntities(a []goram.MessageEntity, b []goram.MessageEntity) bool {
+	return len(a) == 0 && len(b) == 0 || reflect.DeepEqual(a, b)
+}
@@ -0,0 +1,150 @@
+package format
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/TrixiS/goram"
+)
+
+const (
+	MaxMessageLength = 4096 // Max length of a message text in UTF-16 code units
+	MaxCaptionLength = 1024 // Max length of a media caption in UTF-16 code units
+)
+
+var ErrParseMode = errors.New("long messages can't be split with parse mode, use entities instead")
+
+// Part of a long text with entities rebased to the start of the part.
+type Chunk struct {
+	Text     string
+	Entities []goram.MessageEntity
+}
+
+// Splits text into chunks of at most limit UTF-16 code units (see format.MaxMessageLength and format.MaxCaptionLength).
+//
+// Text is split at the last paragraph break in the limit, then at the last line break, then at the last space.
+// A word longer than limit is split as is, but never inside a surrogate pair, even if limit is 1.
+// Whitespace around split points is dropped.
+// Entities crossing a split point are split into both chunks.
+func Split(text string, entities []goram.MessageEntity, limit int) []Chunk {
+	units := utf16Index(text)
+	total := len(units) - 1
+
+	if limit <= 0 || total <= limit {
+		return []Chunk{{Text: text, Entities: entities}}
+	}
+
+	chunks := []Chunk{}
+	start := 0
+
+	for start < total {
+		end := total
+
+		if total-start > limit {
+			end = splitPoint(text, units, start, start+limit)
+		}
+
+		// Drop whitespace at the end of the chunk and at the start of the next one
+		next := end
+		end = start + UTF16Len(strings.TrimRightFunc(text[units[start]:units[end]], unicode.IsSpace))
+		next += UTF16Len(text[units[next]:]) - UTF16Len(strings.TrimLeftFunc(text[units[next]:], unicode.IsSpace))
+
+		if end > start {
+			chunks = append(chunks, Chunk{
+				Text:     text[units[start]:units[end]],
+				Entities: rebaseEntities(entities, start, end, total),
+			})
+		}
+
+		start = next
+	}
+
+	return chunks
+}
+
+// Returns UTF-16 index to split text at, which is greater than start and not greater than limit.
+func splitPoint(text string, units []int, start int, limit int) int {
+	window := text[units[start]:units[limit]]
+
+	for _, separator := range []string{"\n\n", "\n", " "} {
+		if i := strings.LastIndex(window, separator); i > 0 {
+			return start + UTF16Len(window[:i+len(separator)])
+		}
+	}
+
+	// Do not split a surrogate pair. If the pair is the only rune in the limit, it is kept whole
+	if units[limit] == units[limit-1] {
+		if limit-1 > start {
+			return limit - 1
+		}
+
+		return limit + 1
+	}
+
+	return limit
+}
+
+func rebaseEntities(entities []goram.MessageEntity, start int, end int, total int) []goram.MessageEntity {
+	result := []goram.MessageEntity{}
+
+	for _, e := range entities {
+		eStart, eEnd := entityBounds(e, total)
+		eStart, eEnd = max(eStart, start), min(eEnd, end)
+
+		if eStart >= eEnd {
+			continue
+		}
+
+		e.Offset = int64(eStart - start)
+		e.Length = eEnd - eStart
+		result = append(result, e)
+	}
+
+	return result
+}
+
+// Splits request.Text with request.Entities into chunks of format.MaxMessageLength and sends them in order.
+//
+// request.ParseMode must be empty, use Node.Entities() to get entities.
+// request.ReplyMarkup is attached to the last chunk only. If replyToPrevious is true,
+// every chunk after the first one is sent as a reply to the previous chunk.
+//
+// Returns sent messages. If a chunk fails to send, returns messages sent before it and the error.
+func SendMessage(
+	ctx context.Context,
+	bot *goram.Bot,
+	request *goram.SendMessageRequest,
+	replyToPrevious bool,
+) ([]*goram.Message, error) {
+	if request.ParseMode != "" {
+		return nil, ErrParseMode
+	}
+
+	chunks := Split(request.Text, request.Entities, MaxMessageLength)
+	messages := make([]*goram.Message, 0, len(chunks))
+
+	for i, chunk := range chunks {
+		r := *request
+		r.Text, r.Entities = chunk.Text, chunk.Entities
+
+		if i < len(chunks)-1 {
+			r.ReplyMarkup = nil
+		}
+
+		if i > 0 && replyToPrevious {
+			r.ReplyParameters = &goram.ReplyParameters{MessageID: messages[i-1].MessageID}
+		}
+
+		message, err := bot.SendMessage(ctx, &r)
+
+		if err != nil {
+			return messages, err
+		}
+
+		messages = append(messages, message)
+	}
+
+	return messages, nil
+}
@@ -0,0 +1,97 @@
+package format
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/TrixiS/goram"
+)
+
+func TestSplit(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		entities []goram.MessageEntity
+		limit    int
+		want     []Chunk
+	}{
+		{
+			name:  "under limit",
+			text:  "ab cd",
+			limit: 5,
+			want:  []Chunk{{Text: "ab cd"}},
+		},
+		{
+			name:     "entity across split point",
+			text:     "aaaa bbbb",
+			entities: []goram.MessageEntity{{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 9}},
+			limit:    5,
+			want: []Chunk{
+				{Text: "aaaa", Entities: []goram.MessageEntity{{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 4}}},
+				{Text: "bbbb", Entities: []goram.MessageEntity{{Type: goram.MessageEntityTypeBold, Offset: 0, Length: 4}}},
+			},
+		},
+		{
+			name:     "entity with emoji across split point",
+			text:     "ab 😀c de",
+			entities: []goram.MessageEntity{{Type: goram.MessageEntityTypeItalic, Offset: 1, Length: 7}},
+			limit:    6,
+			want: []Chunk{
+				{Text: "ab", Entities: []goram.MessageEntity{{Type: goram.MessageEntityTypeItalic, Offset: 1, Length: 1}}},
+				{Text: "😀c de", Entities: []goram.MessageEntity{{Type: goram.MessageEntityTypeItalic, Offset: 0, Length: 5}}},
+			},
+		},
+		{
+			name:  "paragraph break first",
+			text:  "a b\n\nc",
+			limit: 5,
+			want:  []Chunk{{Text: "a b", Entities: []goram.MessageEntity{}}, {Text: "c", Entities: []goram.MessageEntity{}}},
+		},
+		{
+			name:  "surrogate pairs are not split",
+			text:  "😀😀😀",
+			limit: 3,
+			want: []Chunk{
+				{Text: "😀", Entities: []goram.MessageEntity{}},
+				{Text: "😀", Entities: []goram.MessageEntity{}},
+				{Text: "😀", Entities: []goram.MessageEntity{}},
+			},
+		},
+		{
+			name:  "surrogate pair longer than limit",
+			text:  "😀😀",
+			limit: 1,
+			want: []Chunk{
+				{Text: "😀", Entities: []goram.MessageEntity{}},
+				{Text: "😀", Entities: []goram.MessageEntity{}},
+			},
+		},
+		{
+			name:  "surrogate pair after text longer than limit",
+			text:  "a😀",
+			limit: 1,
+			want: []Chunk{
+				{Text: "a", Entities: []goram.MessageEntity{}},
+				{Text: "😀", Entities: []goram.MessageEntity{}},
+			},
+		},
+		{
+			name:     "long word inside entity",
+			text:     "abcdef",
+			entities: []goram.MessageEntity{{Type: goram.MessageEntityTypeCode, Offset: 2, Length: 4}},
+			limit:    4,
+			want: []Chunk{
+				{Text: "abcd", Entities: []goram.MessageEntity{{Type: goram.MessageEntityTypeCode, Offset: 2, Length: 2}}},
+				{Text: "ef", Entities: []goram.MessageEntity{{Type: goram.MessageEntityTypeCode, Offset: 0, Length: 2}}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Split(tt.text, tt.entities, tt.limit); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Split() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package format
+
+import "unicode/utf8"
+
+// Returns length of the text in UTF-16 code units, which are used in goram.MessageEntity offsets and lengths.
+func UTF16Len(text string) int {
+	n := 0
+
+	for _, r := range text {
+		n += utf16RuneLen(r)
+	}
+
+	return n
+}
+
+func utf16RuneLen(r rune) int {
+	if r >= 0x10000 && r <= utf8.MaxRune {
+		return 2
+	}
+
+	return 1
+}
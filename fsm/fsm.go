@@ -0,0 +1,241 @@
+package fsm
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	"github.com/TrixiS/goram"
+	"github.com/TrixiS/goram/handlers"
+)
+
+// handlers.Data key for *fsm.Context
+var Key = handlers.NewKey[*Context]("fsmContext")
+
+// Matches any non-empty state. See fsm.State()
+const AnyState = "*"
+
+var ErrNoContext = errors.New("fsm: no context in handler data, did you forget to add fsm.Middleware?")
+
+// Identifies a conversation in a storage. Which fields are set depends on the used fsm.Strategy.
+type StorageKey struct {
+	BotID    int64
+	ChatID   int64
+	UserID   int64
+	ThreadID int64
+}
+
+func (k StorageKey) String() string {
+	buf := make([]byte, 0, 64)
+	buf = strconv.AppendInt(buf, k.BotID, 10)
+	buf = append(buf, ':')
+	buf = strconv.AppendInt(buf, k.ChatID, 10)
+	buf = append(buf, ':')
+	buf = strconv.AppendInt(buf, k.UserID, 10)
+	buf = append(buf, ':')
+	buf = strconv.AppendInt(buf, k.ThreadID, 10)
+	return string(buf)
+}
+
+// Determines how conversations are keyed in a storage.
+type Strategy int
+
+const (
+	UserInChat  Strategy = iota // Separate state for every user in every chat. This is the default
+	Chat                        // One state for the whole chat
+	GlobalUser                  // One state for a user in all chats
+	UserInTopic                 // Separate state for every user in every forum topic
+	Topic                       // One state for the whole forum topic
+)
+
+func (s Strategy) key(botID int64, chatID int64, userID int64, threadID int64) StorageKey {
+	switch s {
+	case Chat:
+		return StorageKey{BotID: botID, ChatID: chatID}
+	case GlobalUser:
+		return StorageKey{BotID: botID, UserID: userID}
+	case UserInTopic:
+		return StorageKey{BotID: botID, ChatID: chatID, UserID: userID, ThreadID: threadID}
+	case Topic:
+		return StorageKey{BotID: botID, ChatID: chatID, ThreadID: threadID}
+	default:
+		return StorageKey{BotID: botID, ChatID: chatID, UserID: userID}
+	}
+}
+
+// Conversation state and data accessor. It is put to handlers.Data by fsm.Middleware.
+type Context struct {
+	Key     StorageKey
+	storage Storage
+}
+
+func NewContext(storage Storage, key StorageKey) *Context {
+	return &Context{Key: key, storage: storage}
+}
+
+// Returns fsm context put to handler data by fsm.Middleware.
+func FromData(data handlers.Data) (*Context, bool) {
+	return Key.Get(data)
+}
+
+// Returns current state. Empty string means no state.
+func (c *Context) Get(ctx context.Context) (string, error) {
+	return c.storage.GetState(ctx, c.Key)
+}
+
+// Sets current state. Data remains unchanged.
+func (c *Context) Set(ctx context.Context, state string) error {
+	return c.storage.SetState(ctx, c.Key, state)
+}
+
+// Returns conversation data. The returned map is never nil.
+func (c *Context) Data(ctx context.Context) (map[string]any, error) {
+	data, err := c.storage.GetData(ctx, c.Key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	if data == nil {
+		data = map[string]any{}
+	}
+
+	return data, nil
+}
+
+// Replaces conversation data.
+func (c *Context) SetData(ctx context.Context, data map[string]any) error {
+	return c.storage.SetData(ctx, c.Key, data)
+}
+
+// Merges provided values into conversation data and returns the result.
+//
+// Read and write are separate storage calls, so concurrent updates of the same key may overwrite each other.
+func (c *Context) Update(ctx context.Context, values map[string]any) (map[string]any, error) {
+	data, err := c.Data(ctx)
+
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range values {
+		data[k] = v
+	}
+
+	return data, c.storage.SetData(ctx, c.Key, data)
+}
+
+// Removes both state and data.
+func (c *Context) Clear(ctx context.Context) error {
+	if err := c.storage.SetState(ctx, c.Key, ""); err != nil {
+		return err
+	}
+
+	return c.storage.SetData(ctx, c.Key, nil)
+}
+
+// Creates a router-wide middleware that puts *fsm.Context to handler data.
+// Use it with Router.OuterMiddleware() so that fsm.State filters could see the context.
+//
+// Updates without a chat or a user (like polls) get no context.
+func Middleware(storage Storage, strategy Strategy) handlers.Middleware[any] {
+	return func(next handlers.Func[any]) handlers.Func[any] {
+		return func(ctx context.Context, bot *goram.Bot, update any, data handlers.Data) error {
+			chatID, userID, threadID, ok := extractIDs(update)
+
+			if ok {
+				key := strategy.key(bot.ID(), chatID, userID, threadID)
+				Key.Set(data, NewContext(storage, key))
+			}
+
+			return next(ctx, bot, update, data)
+		}
+	}
+}
+
+// Creates a filter that passes if the current state equals to any of the provided states.
+//
+// Pass "" to match updates without a state and fsm.AnyState to match any non-empty state.
+// Returns fsm.ErrNoContext if there is no fsm.Context in handler data.
+func State[U any](states ...string) handlers.Filter[U] {
+	return func(ctx context.Context, bot *goram.Bot, update U, data handlers.Data) (bool, error) {
+		c, ok := FromData(data)
+
+		if !ok {
+			return false, ErrNoContext
+		}
+
+		current, err := c.Get(ctx)
+
+		if err != nil {
+			return false, err
+		}
+
+		for _, state := range states {
+			if state == current || (state == AnyState && current != "") {
+				return true, nil
+			}
+		}
+
+		return false, nil
+	}
+}
+
+func extractIDs(update any) (chatID int64, userID int64, threadID int64, ok bool) {
+	switch u := update.(type) {
+	case *goram.Message:
+		return messageIDs(u)
+	case *goram.CallbackQuery:
+		if u.Message != nil {
+			chatID, _, threadID, _ = messageIDs(u.Message)
+			return chatID, u.From.ID, threadID, true
+		}
+
+		return u.From.ID, u.From.ID, 0, true
+	case *goram.InlineQuery:
+		return u.From.ID, u.From.ID, 0, true
+	case *goram.ChosenInlineResult:
+		return u.From.ID, u.From.ID, 0, true
+	case *goram.ShippingQuery:
+		return u.From.ID, u.From.ID, 0, true
+	case *goram.PreCheckoutQuery:
+		return u.From.ID, u.From.ID, 0, true
+	case *goram.PollAnswer:
+		if u.User != nil {
+			return u.User.ID, u.User.ID, 0, true
+		}
+	case *goram.ChatMemberUpdated:
+		return u.Chat.ID, u.From.ID, 0, true
+	case *goram.ChatJoinRequest:
+		return u.Chat.ID, u.From.ID, 0, true
+	case *goram.MessageReactionUpdated:
+		if u.User != nil {
+			return u.Chat.ID, u.User.ID, 0, true
+		}
+	}
+
+	return 0, 0, 0, false
+}
+
+func messageIDs(m *goram.Message) (chatID int64, userID int64, threadID int64, ok bool) {
+	if m.Chat == nil {
+		return 0, 0, 0, false
+	}
+
+	chatID = m.Chat.ID
+
+	switch {
+	case m.From != nil:
+		userID = m.From.ID
+	case m.SenderChat != nil:
+		userID = m.SenderChat.ID
+	default:
+		userID = chatID
+	}
+
+	if m.IsTopicMessage {
+		threadID = m.MessageThreadID
+	}
+
+	return chatID, userID, threadID, true
+}
@@ -0,0 +1,174 @@
+package fsm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"sync"
+
+	"github.com/TrixiS/goram/internal/fileutil"
+)
+
+// Conversation state and data storage. Implementations should be safe for concurrent use.
+type Storage interface {
+	GetState(ctx context.Context, key StorageKey) (string, error)
+	SetState(ctx context.Context, key StorageKey, state string) error       // Empty state removes the state
+	GetData(ctx context.Context, key StorageKey) (map[string]any, error)    // Returns nil if there is no data
+	SetData(ctx context.Context, key StorageKey, data map[string]any) error // Empty data removes the data
+}
+
+type record struct {
+	State string         `json:"state,omitempty"`
+	Data  map[string]any `json:"data,omitempty"`
+}
+
+// In-memory storage. All states are lost on restart.
+type MemoryStorage struct {
+	mu      sync.RWMutex
+	records map[StorageKey]record
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{records: make(map[StorageKey]record)}
+}
+
+func (m *MemoryStorage) GetState(ctx context.Context, key StorageKey) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.records[key].State, nil
+}
+
+func (m *MemoryStorage) SetState(ctx context.Context, key StorageKey, state string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r := m.records[key]
+	r.State = state
+	m.put(key, r)
+	return nil
+}
+
+func (m *MemoryStorage) GetData(ctx context.Context, key StorageKey) (map[string]any, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return copyData(m.records[key].Data), nil
+}
+
+func (m *MemoryStorage) SetData(ctx context.Context, key StorageKey, data map[string]any) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	r := m.records[key]
+	r.Data = copyData(data)
+	m.put(key, r)
+	return nil
+}
+
+func (m *MemoryStorage) put(key StorageKey, r record) {
+	if r.State == "" && len(r.Data) == 0 {
+		delete(m.records, key)
+		return
+	}
+
+	m.records[key] = r
+}
+
+// Storage that keeps everything in memory and writes a JSON snapshot to a file on every change.
+//
+// Data values are JSON encoded, so after a restart they are decoded as JSON values:
+// numbers become float64, structs become map[string]any and so on.
+type FileStorage struct {
+	path   string
+	mu     sync.Mutex
+	memory *MemoryStorage
+}
+
+// Creates a file storage and loads existing states from the file (if it exists).
+func NewFileStorage(path string) (*FileStorage, error) {
+	f := &FileStorage{path: path, memory: NewMemoryStorage()}
+
+	content, err := os.ReadFile(path)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return f, nil
+		}
+
+		return nil, err
+	}
+
+	records := []fileRecord{}
+
+	if err := json.Unmarshal(content, &records); err != nil {
+		return nil, err
+	}
+
+	for _, r := range records {
+		f.memory.records[r.Key] = r.record
+	}
+
+	return f, nil
+}
+
+type fileRecord struct {
+	Key StorageKey `json:"key"`
+	record
+}
+
+func (f *FileStorage) GetState(ctx context.Context, key StorageKey) (string, error) {
+	return f.memory.GetState(ctx, key)
+}
+
+func (f *FileStorage) SetState(ctx context.Context, key StorageKey, state string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.memory.SetState(ctx, key, state)
+	return f.save()
+}
+
+func (f *FileStorage) GetData(ctx context.Context, key StorageKey) (map[string]any, error) {
+	return f.memory.GetData(ctx, key)
+}
+
+func (f *FileStorage) SetData(ctx context.Context, key StorageKey, data map[string]any) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.memory.SetData(ctx, key, data)
+	return f.save()
+}
+
+// Writes a snapshot of all records to the file.
+func (f *FileStorage) save() error {
+	f.memory.mu.RLock()
+	records := make([]fileRecord, 0, len(f.memory.records))
+
+	for key, r := range f.memory.records {
+		records = append(records, fileRecord{Key: key, record: r})
+	}
+
+	content, err := json.Marshal(records)
+	f.memory.mu.RUnlock()
+
+	if err != nil {
+		return err
+	}
+
+	return fileutil.WriteFileAtomic(f.path, content)
+}
+
+func copyData(data map[string]any) map[string]any {
+	if len(data) == 0 {
+		return nil
+	}
+
+	copied := make(map[string]any, len(data))
+
+	for k, v := range data {
+		copied[k] = v
+	}
+
+	return copied
+}
@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/TrixiS/goram"
+)
+
+// Router tree summary. See Router.Describe().
+type RouterInfo struct {
+	Name               string
+	Handlers           map[goram.UpdateType]int // Amount of handlers per update type
+	MediaGroupHandlers int
+	Children           []RouterInfo
+}
+
+// Calls fn for the router and all of its descendants depth-first.
+// depth is 0 for the router itself. If fn returns false, children of that router are skipped.
+func (r *Router) Walk(fn func(router *Router, depth int) bool) {
+	r.walk(fn, 0)
+}
+
+func (r *Router) walk(fn func(router *Router, depth int) bool, depth int) {
+	if !fn(r, depth) {
+		return
+	}
+
+	for _, child := range r.children {
+		child.walk(fn, depth+1)
+	}
+}
+
+// Returns the router tree with handler counts, for example for logging on startup.
+func (r *Router) Describe() RouterInfo {
+	info := RouterInfo{
+		Name:               r.Options.Name,
+		Handlers:           r.handlerCounts(),
+		MediaGroupHandlers: len(r.mediaGroupHandlers),
+	}
+
+	for _, child := range r.children {
+		info.Children = append(info.Children, child.Describe())
+	}
+
+	return info
+}
+
+// Returns the tree as indented lines, like:
+//
+//	root: message=2 callback_query=1
+//	  admin: message=3
+func (i RouterInfo) String() string {
+	b := strings.Builder{}
+	i.write(&b, 0)
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+func (i RouterInfo) write(b *strings.Builder, depth int) {
+	name := i.Name
+
+	if name == "" {
+		name = "<unnamed>"
+	}
+
+	b.WriteString(strings.Repeat("  ", depth) + name + ":")
+
+	types := make([]string, 0, len(i.Handlers))
+
+	for updateType := range i.Handlers {
+		types = append(types, string(updateType))
+	}
+
+	sort.Strings(types)
+
+	for _, updateType := range types {
+		fmt.Fprintf(b, " %s=%d", updateType, i.Handlers[goram.UpdateType(updateType)])
+	}
+
+	if i.MediaGroupHandlers > 0 {
+		fmt.Fprintf(b, " media_group=%d", i.MediaGroupHandlers)
+	}
+
+	b.WriteString("\n")
+
+	for _, child := range i.Children {
+		child.write(b, depth+1)
+	}
+}
+
+// Returns true if pred returns true for the router or any of its descendants.
+func (r *Router) anyRouter(pred func(r *Router) bool) bool {
+	found := false
+
+	r.Walk(func(router *Router, depth int) bool {
+		found = found || pred(router)
+		return !found
+	})
+
+	return found
+}
+
+// Returns true if there are handlers, fallback handlers or middlewares for the update type.
+func (h *routerHandlers[T]) used() bool {
+	return len(h.handlers) > 0 || len(h.fallback) > 0 || len(h.outer) > 0 || len(h.inner) > 0
+}
@@ -0,0 +1,398 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+
+	"github.com/TrixiS/goram"
+)
+
+const (
+	DefaultDispatcherWorkers   = 16
+	DefaultDispatcherQueueSize = 64
+)
+
+var ErrDispatcherStopped = errors.New("dispatcher is stopped")
+
+// Returns a key of the update. Updates with the same key are processed sequentially in the order they were fed.
+type KeyFunc func(update *goram.Update) int64
+
+// Gets called when the router returns an error for an update (or for messages of an album).
+type DispatcherErrorFunc func(ctx context.Context, bot *goram.Bot, updates []goram.Update, err error)
+
+type DispatcherOptions struct {
+	Workers   int                 // Optional. Amount of worker goroutines. Default is handlers.DefaultDispatcherWorkers
+	QueueSize int                 // Optional. Max amount of pending updates per worker, an album counts as one. Default is handlers.DefaultDispatcherQueueSize
+	Key       KeyFunc             // Optional. Default is handlers.ChatKey
+	OnError   DispatcherErrorFunc // Optional
+}
+
+// An update or messages of an album, which are fed to the router together.
+type dispatcherJob struct {
+	updates []goram.Update
+	done    *feedDone // optional
+}
+
+type releaseWorkerKey struct{}
+
+// Tracks processing of updates fed with .FeedWait(). Jobs dropped on dispatcher ctx cancellation never finish,
+// so a channel is used instead of sync.WaitGroup to let the waiter give up without leaking a goroutine.
+type feedDone struct {
+	pending atomic.Int64
+	c       chan struct{}
+}
+
+func newFeedDone() *feedDone {
+	return &feedDone{c: make(chan struct{})}
+}
+
+func (d *feedDone) add(n int) {
+	if n == 0 {
+		close(d.c)
+		return
+	}
+
+	d.pending.Add(int64(n))
+}
+
+func (d *feedDone) jobDone() {
+	if d.pending.Add(-1) == 0 {
+		close(d.c)
+	}
+}
+
+// Concurrent update dispatcher. Feeds updates to the router using a fixed pool of workers.
+//
+// Every key (see handlers.KeyFunc) is bound to a single worker, so updates with the same key
+// are processed in order while updates with different keys are processed concurrently.
+// Note that a slow handler delays other keys bound to the same worker too.
+//
+// Feeding blocks if the worker queue is full, which slows down the update source (backpressure).
+// Router interceptors (see Router.Intercept()) are called before updates are queued.
+// A handler that waits for the next update has to call handlers.ReleaseWorker() first (conversation.Conversation does).
+// Every update gets new empty handlers.Data.
+type Dispatcher struct {
+	Options DispatcherOptions
+
+	ctx     context.Context
+	bot     *goram.Bot
+	router  *Router
+	queues  []chan dispatcherJob
+	mu      sync.RWMutex
+	stopped bool
+	wg      sync.WaitGroup
+}
+
+// Creates a dispatcher and starts its workers.
+//
+// ctx is passed to handlers. If ctx is done, workers exit right after their current batch
+// and pending updates are dropped. Use .Stop() for graceful shutdown.
+func NewDispatcher(ctx context.Context, bot *goram.Bot, router *Router, options DispatcherOptions) *Dispatcher {
+	if options.Workers <= 0 {
+		options.Workers = DefaultDispatcherWorkers
+	}
+
+	if options.QueueSize <= 0 {
+		options.QueueSize = DefaultDispatcherQueueSize
+	}
+
+	if options.Key == nil {
+		options.Key = ChatKey
+	}
+
+	d := &Dispatcher{
+		Options: options,
+		ctx:     ctx,
+		bot:     bot,
+		router:  router,
+		queues:  make([]chan dispatcherJob, options.Workers),
+	}
+
+	d.wg.Add(len(d.queues))
+
+	for i := range d.queues {
+		d.queues[i] = make(chan dispatcherJob, options.QueueSize)
+		go d.work(d.queues[i])
+	}
+
+	return d
+}
+
+// Splits updates by key and queues them to workers. Order of updates with the same key is preserved.
+// Messages of the same album in updates are queued together.
+//
+// Blocks while a worker queue is full. Returns ctx error if ctx is done while waiting
+// and handlers.ErrDispatcherStopped if the dispatcher is stopped.
+func (d *Dispatcher) Feed(ctx context.Context, updates []goram.Update) error {
+	return d.feed(ctx, updates, nil)
+}
+
+// Does the same as .Feed(), but also waits for the updates to be processed.
+// Use it as goram.PollerOptions.OnUpdates with goram.PollerOptions.OffsetStore,
+// so the offset is saved only after the router has finished processing a batch.
+// Albums collected in background (see RouterOptions.MediaGroupWait) and handlers
+// that called handlers.ReleaseWorker() are not waited for.
+//
+// Returns handlers.ErrDispatcherStopped if the dispatcher ctx is done before the updates are processed.
+func (d *Dispatcher) FeedWait(ctx context.Context, updates []goram.Update) error {
+	done := newFeedDone()
+
+	if err := d.feed(ctx, updates, done); err != nil {
+		return err
+	}
+
+	select {
+	case <-done.c:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-d.ctx.Done():
+		return ErrDispatcherStopped
+	}
+}
+
+func (d *Dispatcher) feed(ctx context.Context, updates []goram.Update, done *feedDone) error {
+	jobs := make(map[int][]dispatcherJob)
+	order := []int{}
+	albums := make(map[string][2]int) // worker and job index of the album
+	total := 0
+
+	for i := range updates {
+		u := &updates[i]
+
+		// intercepted updates are not queued, so a handler waiting for the next update
+		// of its own key (see conversation.Manager) does not block it
+		if d.router.intercept(ctx, d.bot, u) {
+			continue
+		}
+
+		worker := d.worker(d.Options.Key(u))
+
+		if isMediaGroupUpdate(u) {
+			key := mediaGroupKey(u.Message)
+
+			if album, exists := albums[key]; exists {
+				job := &jobs[album[0]][album[1]]
+				job.updates = append(job.updates, *u)
+				continue
+			}
+
+			albums[key] = [2]int{worker, len(jobs[worker])}
+		}
+
+		if _, exists := jobs[worker]; !exists {
+			order = append(order, worker)
+		}
+
+		jobs[worker] = append(jobs[worker], dispatcherJob{updates: []goram.Update{*u}, done: done})
+		total++
+	}
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.stopped {
+		return ErrDispatcherStopped
+	}
+
+	if done != nil {
+		done.add(total)
+	}
+
+	for _, worker := range order {
+		for _, job := range jobs[worker] {
+			select {
+			case d.queues[worker] <- job:
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-d.ctx.Done():
+				return ErrDispatcherStopped
+			}
+		}
+	}
+
+	return nil
+}
+
+// Does the same as .Feed() for a single update. Can be used as webhook.ServerOptions.OnUpdate.
+func (d *Dispatcher) FeedUpdate(ctx context.Context, update *goram.Update) error {
+	return d.Feed(ctx, []goram.Update{*update})
+}
+
+// Feeds updates from the channel (for example, from goram.LongPollUpdates) until it gets closed or ctx is done.
+// Stops the dispatcher gracefully before returning.
+func (d *Dispatcher) Run(ctx context.Context, c <-chan []goram.Update) error {
+	defer d.Stop()
+
+	for {
+		select {
+		case updates, ok := <-c:
+			if !ok {
+				return nil
+			}
+
+			if err := d.Feed(ctx, updates); err != nil {
+				return err
+			}
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Stops accepting new updates and waits for queued updates to be processed.
+// Handlers that released their workers (see handlers.ReleaseWorker()) are waited for too,
+// cancel the dispatcher ctx to interrupt them. Flushes router media groups collected in background after that.
+func (d *Dispatcher) Stop() {
+	d.mu.Lock()
+
+	if !d.stopped {
+		d.stopped = true
+
+		for _, q := range d.queues {
+			close(q)
+		}
+	}
+
+	d.mu.Unlock()
+
+	d.wg.Wait()
+	d.router.FlushMediaGroups()
+}
+
+func (d *Dispatcher) worker(key int64) int {
+	return int(uint64(key) % uint64(len(d.queues)))
+}
+
+func (d *Dispatcher) work(queue chan dispatcherJob) {
+	defer d.wg.Done()
+
+	for {
+		select {
+		case job, ok := <-queue:
+			if !ok {
+				return
+			}
+
+			// the queue has been passed to a new worker
+			if d.process(queue, job) {
+				return
+			}
+		case <-d.ctx.Done():
+			return
+		}
+	}
+}
+
+// Feeds the job to the router. Returns true if a handler released the worker (see handlers.ReleaseWorker()).
+func (d *Dispatcher) process(queue chan dispatcherJob, job dispatcherJob) bool {
+	finish := sync.Once{}
+	released := atomic.Bool{}
+
+	jobDone := func() {
+		if job.done != nil {
+			job.done.jobDone()
+		}
+	}
+
+	release := func() {
+		finish.Do(func() {
+			released.Store(true)
+			jobDone()
+
+			// Stop() can not return before this worker does, so the new one is waited for too
+			d.wg.Add(1)
+			go d.work(queue)
+		})
+	}
+
+	ctx := context.WithValue(d.ctx, releaseWorkerKey{}, release)
+	_, err := d.router.FeedUpdates(ctx, d.bot, job.updates, Data{})
+
+	if err != nil && d.Options.OnError != nil {
+		d.Options.OnError(d.ctx, d.bot, job.updates, err)
+	}
+
+	finish.Do(jobDone)
+	return released.Load()
+}
+
+// Tells handlers.Dispatcher that the handler is going to block for a long time,
+// for example while it waits for the next update of the user (see conversation.Conversation.Wait()).
+//
+// The rest of the worker queue is passed to a new worker, so other keys bound to the worker are not blocked,
+// and .FeedWait() does not wait for the update anymore. Updates with the same key can be processed
+// concurrently with the handler after that. Does nothing if ctx does not come from a dispatcher.
+func ReleaseWorker(ctx context.Context) {
+	if release, ok := ctx.Value(releaseWorkerKey{}).(func()); ok {
+		release()
+	}
+}
+
+// Returns chat id of the update. If the update has no chat, returns user id.
+// If there is no user too, returns update id, so such updates are not ordered.
+func ChatKey(update *goram.Update) int64 {
+	if message := updateMessage(update); message != nil && message.Chat != nil {
+		return message.Chat.ID
+	}
+
+	switch {
+	case update.CallbackQuery != nil:
+		return update.CallbackQuery.ChatID().ID
+	case update.InlineQuery != nil:
+		return update.InlineQuery.From.ID
+	case update.ChosenInlineResult != nil:
+		return update.ChosenInlineResult.From.ID
+	case update.ShippingQuery != nil:
+		return update.ShippingQuery.From.ID
+	case update.PreCheckoutQuery != nil:
+		return update.PreCheckoutQuery.From.ID
+	case update.PurchasedPaidMedia != nil:
+		return update.PurchasedPaidMedia.From.ID
+	case update.PollAnswer != nil && update.PollAnswer.User != nil:
+		return update.PollAnswer.User.ID
+	case update.PollAnswer != nil && update.PollAnswer.VoterChat != nil:
+		return update.PollAnswer.VoterChat.ID
+	case update.MyChatMember != nil:
+		return update.MyChatMember.Chat.ID
+	case update.ChatMember != nil:
+		return update.ChatMember.Chat.ID
+	case update.ChatJoinRequest != nil:
+		return update.ChatJoinRequest.Chat.ID
+	case update.MessageReaction != nil:
+		return update.MessageReaction.Chat.ID
+	case update.MessageReactionCount != nil:
+		return update.MessageReactionCount.Chat.ID
+	case update.ChatBoost != nil:
+		return update.ChatBoost.Chat.ID
+	case update.RemovedChatBoost != nil:
+		return update.RemovedChatBoost.Chat.ID
+	case update.BusinessConnection != nil:
+		return update.BusinessConnection.UserChatID
+	case update.DeletedBusinessMessages != nil:
+		return update.DeletedBusinessMessages.Chat.ID
+	}
+
+	return update.UpdateID
+}
+
+func updateMessage(update *goram.Update) *goram.Message {
+	switch {
+	case update.Message != nil:
+		return update.Message
+	case update.EditedMessage != nil:
+		return update.EditedMessage
+	case update.ChannelPost != nil:
+		return update.ChannelPost
+	case update.EditedChannelPost != nil:
+		return update.EditedChannelPost
+	case update.BusinessMessage != nil:
+		return update.BusinessMessage
+	case update.EditedBusinessMessage != nil:
+		return update.EditedBusinessMessage
+	}
+
+	return nil
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/TrixiS/goram"
+)
+
+func testMessageUpdate(updateID int64, chatID int64, messageID int) goram.Update {
+	return goram.Update{
+		UpdateID: updateID,
+		Message:  &goram.Message{MessageID: messageID, Chat: &goram.Chat{ID: chatID}},
+	}
+}
+
+func TestDispatcherOrdersUpdatesOfChat(t *testing.T) {
+	const chats, perChat = 5, 50
+
+	mu := sync.Mutex{}
+	got := map[int64][]int{}
+	router := NewRouter(RouterOptions{})
+
+	router.Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+		// let updates of other chats overtake
+		if message.MessageID%7 == 0 {
+			time.Sleep(time.Millisecond)
+		}
+
+		mu.Lock()
+		got[message.Chat.ID] = append(got[message.Chat.ID], message.MessageID)
+		mu.Unlock()
+		return nil
+	})
+
+	d := NewDispatcher(context.Background(), nil, router, DispatcherOptions{Workers: 3})
+	updateID := int64(0)
+
+	for i := 0; i < perChat; i++ {
+		batch := []goram.Update{}
+
+		for chat := int64(1); chat <= chats; chat++ {
+			updateID++
+			batch = append(batch, testMessageUpdate(updateID, chat, i))
+		}
+
+		if err := d.Feed(context.Background(), batch); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d.Stop()
+
+	for chat := int64(1); chat <= chats; chat++ {
+		if len(got[chat]) != perChat {
+			t.Fatalf("chat %d: got %d updates, want %d", chat, len(got[chat]), perChat)
+		}
+
+		for i, messageID := range got[chat] {
+			if messageID != i {
+				t.Fatalf("chat %d: got message %d at %d", chat, messageID, i)
+			}
+		}
+	}
+}
+
+func TestDispatcherStopDrainsQueues(t *testing.T) {
+	const total = 100
+
+	handled := atomic.Int64{}
+	router := NewRouter(RouterOptions{})
+
+	router.Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+		time.Sleep(time.Millisecond)
+		handled.Add(1)
+		return nil
+	})
+
+	d := NewDispatcher(context.Background(), nil, router, DispatcherOptions{Workers: 2, QueueSize: total})
+
+	for i := 0; i < total; i++ {
+		if err := d.Feed(context.Background(), []goram.Update{testMessageUpdate(int64(i), int64(i%4), i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	d.Stop()
+
+	if n := handled.Load(); n != total {
+		t.Fatalf("handled %d updates, want %d", n, total)
+	}
+
+	if err := d.Feed(context.Background(), []goram.Update{testMessageUpdate(total, 1, total)}); !errors.Is(err, ErrDispatcherStopped) {
+		t.Fatalf("Feed() after Stop() error = %v, want %v", err, ErrDispatcherStopped)
+	}
+}
+
+func TestDispatcherFeedWait(t *testing.T) {
+	handled := atomic.Int64{}
+	router := NewRouter(RouterOptions{})
+
+	router.Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+		time.Sleep(time.Millisecond * 5)
+		handled.Add(1)
+		return nil
+	})
+
+	d := NewDispatcher(context.Background(), nil, router, DispatcherOptions{Workers: 4})
+	defer d.Stop()
+
+	updates := []goram.Update{}
+
+	for i := 0; i < 10; i++ {
+		updates = append(updates, testMessageUpdate(int64(i), int64(i), i))
+	}
+
+	if err := d.FeedWait(context.Background(), updates); err != nil {
+		t.Fatal(err)
+	}
+
+	if n := handled.Load(); n != int64(len(updates)) {
+		t.Fatalf("FeedWait() returned after %d updates, want %d", n, len(updates))
+	}
+
+	if err := d.FeedWait(context.Background(), nil); err != nil {
+		t.Fatalf("FeedWait() with no updates error = %v", err)
+	}
+}
+
+func TestDispatcherBackpressure(t *testing.T) {
+	release := make(chan struct{})
+	router := NewRouter(RouterOptions{})
+
+	router.Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+		<-release
+		return nil
+	})
+
+	d := NewDispatcher(context.Background(), nil, router, DispatcherOptions{Workers: 1, QueueSize: 1})
+
+	defer func() {
+		close(release)
+		d.Stop()
+	}()
+
+	// the first batch is taken by the worker, the second one fills the queue
+	for i := 0; i < 2; i++ {
+		if err := d.Feed(context.Background(), []goram.Update{testMessageUpdate(int64(i), 1, i)}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	err := d.Feed(ctx, []goram.Update{testMessageUpdate(2, 1, 2)})
+
+	// the worker could have not taken the first batch yet, then the queue is full one batch earlier
+	if err == nil {
+		err = d.Feed(ctx, []goram.Update{testMessageUpdate(3, 1, 3)})
+	}
+
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Feed() to a full queue error = %v, want %v", err, context.DeadlineExceeded)
+	}
+}
+
+func TestDispatcherCtxCancel(t *testing.T) {
+	release := make(chan struct{})
+	defer close(release)
+
+	router := NewRouter(RouterOptions{})
+
+	router.Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	d := NewDispatcher(ctx, nil, router, DispatcherOptions{Workers: 1})
+	result := make(chan error, 1)
+
+	go func() {
+		result <- d.FeedWait(context.Background(), []goram.Update{testMessageUpdate(1, 1, 1), testMessageUpdate(2, 2, 2)})
+	}()
+
+	cancel()
+
+	select {
+	case err := <-result:
+		if !errors.Is(err, ErrDispatcherStopped) && err != nil {
+			t.Fatalf("FeedWait() error = %v, want %v", err, ErrDispatcherStopped)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("FeedWait() did not return after dispatcher ctx cancellation")
+	}
+
+	d.Stop()
+}
@@ -286,6 +286,282 @@ func (r *Router) RemovedChatBoost(handlerFunc Func[*goram.ChatBoostRemoved], fil
 	return r
 }
 
+// Add Message fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackMessage(handlerFunc Func[*goram.Message], filters ...Filter[*goram.Message]) *Router {
+	h := handler[*goram.Message]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.message.fallback = append(r.handlers.message.fallback, h)
+	return r
+}
+
+// Add EditedMessage fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackEditedMessage(handlerFunc Func[*goram.Message], filters ...Filter[*goram.Message]) *Router {
+	h := handler[*goram.Message]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.editedMessage.fallback = append(r.handlers.editedMessage.fallback, h)
+	return r
+}
+
+// Add ChannelPost fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackChannelPost(handlerFunc Func[*goram.Message], filters ...Filter[*goram.Message]) *Router {
+	h := handler[*goram.Message]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.channelPost.fallback = append(r.handlers.channelPost.fallback, h)
+	return r
+}
+
+// Add EditedChannelPost fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackEditedChannelPost(handlerFunc Func[*goram.Message], filters ...Filter[*goram.Message]) *Router {
+	h := handler[*goram.Message]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.editedChannelPost.fallback = append(r.handlers.editedChannelPost.fallback, h)
+	return r
+}
+
+// Add BusinessConnection fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackBusinessConnection(handlerFunc Func[*goram.BusinessConnection], filters ...Filter[*goram.BusinessConnection]) *Router {
+	h := handler[*goram.BusinessConnection]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.businessConnection.fallback = append(r.handlers.businessConnection.fallback, h)
+	return r
+}
+
+// Add BusinessMessage fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackBusinessMessage(handlerFunc Func[*goram.Message], filters ...Filter[*goram.Message]) *Router {
+	h := handler[*goram.Message]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.businessMessage.fallback = append(r.handlers.businessMessage.fallback, h)
+	return r
+}
+
+// Add EditedBusinessMessage fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackEditedBusinessMessage(handlerFunc Func[*goram.Message], filters ...Filter[*goram.Message]) *Router {
+	h := handler[*goram.Message]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.editedBusinessMessage.fallback = append(r.handlers.editedBusinessMessage.fallback, h)
+	return r
+}
+
+// Add DeletedBusinessMessages fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackDeletedBusinessMessages(handlerFunc Func[*goram.BusinessMessagesDeleted], filters ...Filter[*goram.BusinessMessagesDeleted]) *Router {
+	h := handler[*goram.BusinessMessagesDeleted]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.deletedBusinessMessages.fallback = append(r.handlers.deletedBusinessMessages.fallback, h)
+	return r
+}
+
+// Add MessageReaction fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackMessageReaction(handlerFunc Func[*goram.MessageReactionUpdated], filters ...Filter[*goram.MessageReactionUpdated]) *Router {
+	h := handler[*goram.MessageReactionUpdated]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.messageReaction.fallback = append(r.handlers.messageReaction.fallback, h)
+	return r
+}
+
+// Add MessageReactionCount fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackMessageReactionCount(handlerFunc Func[*goram.MessageReactionCountUpdated], filters ...Filter[*goram.MessageReactionCountUpdated]) *Router {
+	h := handler[*goram.MessageReactionCountUpdated]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.messageReactionCount.fallback = append(r.handlers.messageReactionCount.fallback, h)
+	return r
+}
+
+// Add InlineQuery fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackInlineQuery(handlerFunc Func[*goram.InlineQuery], filters ...Filter[*goram.InlineQuery]) *Router {
+	h := handler[*goram.InlineQuery]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.inlineQuery.fallback = append(r.handlers.inlineQuery.fallback, h)
+	return r
+}
+
+// Add ChosenInlineResult fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackChosenInlineResult(handlerFunc Func[*goram.ChosenInlineResult], filters ...Filter[*goram.ChosenInlineResult]) *Router {
+	h := handler[*goram.ChosenInlineResult]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.chosenInlineResult.fallback = append(r.handlers.chosenInlineResult.fallback, h)
+	return r
+}
+
+// Add CallbackQuery fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackCallbackQuery(handlerFunc Func[*goram.CallbackQuery], filters ...Filter[*goram.CallbackQuery]) *Router {
+	h := handler[*goram.CallbackQuery]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.callbackQuery.fallback = append(r.handlers.callbackQuery.fallback, h)
+	return r
+}
+
+// Add ShippingQuery fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackShippingQuery(handlerFunc Func[*goram.ShippingQuery], filters ...Filter[*goram.ShippingQuery]) *Router {
+	h := handler[*goram.ShippingQuery]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.shippingQuery.fallback = append(r.handlers.shippingQuery.fallback, h)
+	return r
+}
+
+// Add PreCheckoutQuery fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackPreCheckoutQuery(handlerFunc Func[*goram.PreCheckoutQuery], filters ...Filter[*goram.PreCheckoutQuery]) *Router {
+	h := handler[*goram.PreCheckoutQuery]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.preCheckoutQuery.fallback = append(r.handlers.preCheckoutQuery.fallback, h)
+	return r
+}
+
+// Add PurchasedPaidMedia fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackPurchasedPaidMedia(handlerFunc Func[*goram.PaidMediaPurchased], filters ...Filter[*goram.PaidMediaPurchased]) *Router {
+	h := handler[*goram.PaidMediaPurchased]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.purchasedPaidMedia.fallback = append(r.handlers.purchasedPaidMedia.fallback, h)
+	return r
+}
+
+// Add Poll fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackPoll(handlerFunc Func[*goram.Poll], filters ...Filter[*goram.Poll]) *Router {
+	h := handler[*goram.Poll]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.poll.fallback = append(r.handlers.poll.fallback, h)
+	return r
+}
+
+// Add PollAnswer fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackPollAnswer(handlerFunc Func[*goram.PollAnswer], filters ...Filter[*goram.PollAnswer]) *Router {
+	h := handler[*goram.PollAnswer]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.pollAnswer.fallback = append(r.handlers.pollAnswer.fallback, h)
+	return r
+}
+
+// Add MyChatMember fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackMyChatMember(handlerFunc Func[*goram.ChatMemberUpdated], filters ...Filter[*goram.ChatMemberUpdated]) *Router {
+	h := handler[*goram.ChatMemberUpdated]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.myChatMember.fallback = append(r.handlers.myChatMember.fallback, h)
+	return r
+}
+
+// Add ChatMember fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackChatMember(handlerFunc Func[*goram.ChatMemberUpdated], filters ...Filter[*goram.ChatMemberUpdated]) *Router {
+	h := handler[*goram.ChatMemberUpdated]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.chatMember.fallback = append(r.handlers.chatMember.fallback, h)
+	return r
+}
+
+// Add ChatJoinRequest fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackChatJoinRequest(handlerFunc Func[*goram.ChatJoinRequest], filters ...Filter[*goram.ChatJoinRequest]) *Router {
+	h := handler[*goram.ChatJoinRequest]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.chatJoinRequest.fallback = append(r.handlers.chatJoinRequest.fallback, h)
+	return r
+}
+
+// Add ChatBoost fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackChatBoost(handlerFunc Func[*goram.ChatBoostUpdated], filters ...Filter[*goram.ChatBoostUpdated]) *Router {
+	h := handler[*goram.ChatBoostUpdated]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.chatBoost.fallback = append(r.handlers.chatBoost.fallback, h)
+	return r
+}
+
+// Add RemovedChatBoost fallback handler with provided filters.
+// Fallback handlers run if no handler of the router or its children matched the update
+func (r *Router) FallbackRemovedChatBoost(handlerFunc Func[*goram.ChatBoostRemoved], filters ...Filter[*goram.ChatBoostRemoved]) *Router {
+	h := handler[*goram.ChatBoostRemoved]{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.handlers.removedChatBoost.fallback = append(r.handlers.removedChatBoost.fallback, h)
+	return r
+}
+
 // Add router-level filter(s) to Message update
 func (r *Router) FilterMessage(filters ...Filter[*goram.Message]) *Router {
 	r.handlers.message.filters = append(r.handlers.message.filters, filters...)
@@ -424,978 +700,736 @@ func (r *Router) FilterRemovedChatBoost(filters ...Filter[*goram.ChatBoostRemove
 	return r
 }
 
-func (r *Router) callMessageHandlers(ctx context.Context, bot *goram.Bot, update *goram.Message, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.message.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.message.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add inner middleware(s) to Message update. See handlers.Middleware
+func (r *Router) MiddlewareMessage(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.message.inner = append(r.handlers.message.inner, middlewares...)
+	return r
 }
 
-func (r *Router) callEditedMessageHandlers(ctx context.Context, bot *goram.Bot, update *goram.Message, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.editedMessage.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.editedMessage.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add outer middleware(s) to Message update. See handlers.Middleware
+func (r *Router) OuterMiddlewareMessage(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.message.outer = append(r.handlers.message.outer, middlewares...)
+	return r
 }
 
-func (r *Router) callChannelPostHandlers(ctx context.Context, bot *goram.Bot, update *goram.Message, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.channelPost.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.channelPost.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add inner middleware(s) to EditedMessage update. See handlers.Middleware
+func (r *Router) MiddlewareEditedMessage(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.editedMessage.inner = append(r.handlers.editedMessage.inner, middlewares...)
+	return r
 }
 
-func (r *Router) callEditedChannelPostHandlers(ctx context.Context, bot *goram.Bot, update *goram.Message, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.editedChannelPost.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.editedChannelPost.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add outer middleware(s) to EditedMessage update. See handlers.Middleware
+func (r *Router) OuterMiddlewareEditedMessage(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.editedMessage.outer = append(r.handlers.editedMessage.outer, middlewares...)
+	return r
 }
 
-func (r *Router) callBusinessConnectionHandlers(ctx context.Context, bot *goram.Bot, update *goram.BusinessConnection, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.businessConnection.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.businessConnection.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add inner middleware(s) to ChannelPost update. See handlers.Middleware
+func (r *Router) MiddlewareChannelPost(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.channelPost.inner = append(r.handlers.channelPost.inner, middlewares...)
+	return r
 }
 
-func (r *Router) callBusinessMessageHandlers(ctx context.Context, bot *goram.Bot, update *goram.Message, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.businessMessage.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.businessMessage.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add outer middleware(s) to ChannelPost update. See handlers.Middleware
+func (r *Router) OuterMiddlewareChannelPost(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.channelPost.outer = append(r.handlers.channelPost.outer, middlewares...)
+	return r
 }
 
-func (r *Router) callEditedBusinessMessageHandlers(ctx context.Context, bot *goram.Bot, update *goram.Message, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.editedBusinessMessage.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.editedBusinessMessage.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add inner middleware(s) to EditedChannelPost update. See handlers.Middleware
+func (r *Router) MiddlewareEditedChannelPost(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.editedChannelPost.inner = append(r.handlers.editedChannelPost.inner, middlewares...)
+	return r
 }
 
-func (r *Router) callDeletedBusinessMessagesHandlers(ctx context.Context, bot *goram.Bot, update *goram.BusinessMessagesDeleted, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.deletedBusinessMessages.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.deletedBusinessMessages.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add outer middleware(s) to EditedChannelPost update. See handlers.Middleware
+func (r *Router) OuterMiddlewareEditedChannelPost(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.editedChannelPost.outer = append(r.handlers.editedChannelPost.outer, middlewares...)
+	return r
 }
 
-func (r *Router) callMessageReactionHandlers(ctx context.Context, bot *goram.Bot, update *goram.MessageReactionUpdated, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.messageReaction.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.messageReaction.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add inner middleware(s) to BusinessConnection update. See handlers.Middleware
+func (r *Router) MiddlewareBusinessConnection(middlewares ...Middleware[*goram.BusinessConnection]) *Router {
+	r.handlers.businessConnection.inner = append(r.handlers.businessConnection.inner, middlewares...)
+	return r
 }
 
-func (r *Router) callMessageReactionCountHandlers(ctx context.Context, bot *goram.Bot, update *goram.MessageReactionCountUpdated, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.messageReactionCount.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.messageReactionCount.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add outer middleware(s) to BusinessConnection update. See handlers.Middleware
+func (r *Router) OuterMiddlewareBusinessConnection(middlewares ...Middleware[*goram.BusinessConnection]) *Router {
+	r.handlers.businessConnection.outer = append(r.handlers.businessConnection.outer, middlewares...)
+	return r
 }
 
-func (r *Router) callInlineQueryHandlers(ctx context.Context, bot *goram.Bot, update *goram.InlineQuery, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.inlineQuery.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.inlineQuery.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add inner middleware(s) to BusinessMessage update. See handlers.Middleware
+func (r *Router) MiddlewareBusinessMessage(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.businessMessage.inner = append(r.handlers.businessMessage.inner, middlewares...)
+	return r
 }
 
-func (r *Router) callChosenInlineResultHandlers(ctx context.Context, bot *goram.Bot, update *goram.ChosenInlineResult, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.chosenInlineResult.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.chosenInlineResult.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add outer middleware(s) to BusinessMessage update. See handlers.Middleware
+func (r *Router) OuterMiddlewareBusinessMessage(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.businessMessage.outer = append(r.handlers.businessMessage.outer, middlewares...)
+	return r
 }
 
-func (r *Router) callCallbackQueryHandlers(ctx context.Context, bot *goram.Bot, update *goram.CallbackQuery, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.callbackQuery.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.callbackQuery.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add inner middleware(s) to EditedBusinessMessage update. See handlers.Middleware
+func (r *Router) MiddlewareEditedBusinessMessage(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.editedBusinessMessage.inner = append(r.handlers.editedBusinessMessage.inner, middlewares...)
+	return r
 }
 
-func (r *Router) callShippingQueryHandlers(ctx context.Context, bot *goram.Bot, update *goram.ShippingQuery, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.shippingQuery.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
-
-			if !ok {
-				continue queueLoop
-			}
-		}
-
-		found, err := callHandlers(ctx, bot, current.handlers.shippingQuery.handlers, update, data)
-
-		if err != nil || found {
-			return found, err
-		}
-
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
-
-	return false, nil
+// Add outer middleware(s) to EditedBusinessMessage update. See handlers.Middleware
+func (r *Router) OuterMiddlewareEditedBusinessMessage(middlewares ...Middleware[*goram.Message]) *Router {
+	r.handlers.editedBusinessMessage.outer = append(r.handlers.editedBusinessMessage.outer, middlewares...)
+	return r
 }
 
-func (r *Router) callPreCheckoutQueryHandlers(ctx context.Context, bot *goram.Bot, update *goram.PreCheckoutQuery, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
-
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
-
-		for _, filter := range current.handlers.preCheckoutQuery.filters {
-			ok, err := filter(ctx, bot, update, data)
-
-			if err != nil {
-				return ok, err
-			}
+// Add inner middleware(s) to DeletedBusinessMessages update. See handlers.Middleware
+func (r *Router) MiddlewareDeletedBusinessMessages(middlewares ...Middleware[*goram.BusinessMessagesDeleted]) *Router {
+	r.handlers.deletedBusinessMessages.inner = append(r.handlers.deletedBusinessMessages.inner, middlewares...)
+	return r
+}
 
-			if !ok {
-				continue queueLoop
-			}
-		}
+// Add outer middleware(s) to DeletedBusinessMessages update. See handlers.Middleware
+func (r *Router) OuterMiddlewareDeletedBusinessMessages(middlewares ...Middleware[*goram.BusinessMessagesDeleted]) *Router {
+	r.handlers.deletedBusinessMessages.outer = append(r.handlers.deletedBusinessMessages.outer, middlewares...)
+	return r
+}
 
-		found, err := callHandlers(ctx, bot, current.handlers.preCheckoutQuery.handlers, update, data)
+// Add inner middleware(s) to MessageReaction update. See handlers.Middleware
+func (r *Router) MiddlewareMessageReaction(middlewares ...Middleware[*goram.MessageReactionUpdated]) *Router {
+	r.handlers.messageReaction.inner = append(r.handlers.messageReaction.inner, middlewares...)
+	return r
+}
 
-		if err != nil || found {
-			return found, err
-		}
+// Add outer middleware(s) to MessageReaction update. See handlers.Middleware
+func (r *Router) OuterMiddlewareMessageReaction(middlewares ...Middleware[*goram.MessageReactionUpdated]) *Router {
+	r.handlers.messageReaction.outer = append(r.handlers.messageReaction.outer, middlewares...)
+	return r
+}
 
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
+// Add inner middleware(s) to MessageReactionCount update. See handlers.Middleware
+func (r *Router) MiddlewareMessageReactionCount(middlewares ...Middleware[*goram.MessageReactionCountUpdated]) *Router {
+	r.handlers.messageReactionCount.inner = append(r.handlers.messageReactionCount.inner, middlewares...)
+	return r
+}
 
-	return false, nil
+// Add outer middleware(s) to MessageReactionCount update. See handlers.Middleware
+func (r *Router) OuterMiddlewareMessageReactionCount(middlewares ...Middleware[*goram.MessageReactionCountUpdated]) *Router {
+	r.handlers.messageReactionCount.outer = append(r.handlers.messageReactionCount.outer, middlewares...)
+	return r
 }
 
-func (r *Router) callPurchasedPaidMediaHandlers(ctx context.Context, bot *goram.Bot, update *goram.PaidMediaPurchased, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
+// Add inner middleware(s) to InlineQuery update. See handlers.Middleware
+func (r *Router) MiddlewareInlineQuery(middlewares ...Middleware[*goram.InlineQuery]) *Router {
+	r.handlers.inlineQuery.inner = append(r.handlers.inlineQuery.inner, middlewares...)
+	return r
+}
 
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
+// Add outer middleware(s) to InlineQuery update. See handlers.Middleware
+func (r *Router) OuterMiddlewareInlineQuery(middlewares ...Middleware[*goram.InlineQuery]) *Router {
+	r.handlers.inlineQuery.outer = append(r.handlers.inlineQuery.outer, middlewares...)
+	return r
+}
 
-		for _, filter := range current.handlers.purchasedPaidMedia.filters {
-			ok, err := filter(ctx, bot, update, data)
+// Add inner middleware(s) to ChosenInlineResult update. See handlers.Middleware
+func (r *Router) MiddlewareChosenInlineResult(middlewares ...Middleware[*goram.ChosenInlineResult]) *Router {
+	r.handlers.chosenInlineResult.inner = append(r.handlers.chosenInlineResult.inner, middlewares...)
+	return r
+}
 
-			if err != nil {
-				return ok, err
-			}
+// Add outer middleware(s) to ChosenInlineResult update. See handlers.Middleware
+func (r *Router) OuterMiddlewareChosenInlineResult(middlewares ...Middleware[*goram.ChosenInlineResult]) *Router {
+	r.handlers.chosenInlineResult.outer = append(r.handlers.chosenInlineResult.outer, middlewares...)
+	return r
+}
 
-			if !ok {
-				continue queueLoop
-			}
-		}
+// Add inner middleware(s) to CallbackQuery update. See handlers.Middleware
+func (r *Router) MiddlewareCallbackQuery(middlewares ...Middleware[*goram.CallbackQuery]) *Router {
+	r.handlers.callbackQuery.inner = append(r.handlers.callbackQuery.inner, middlewares...)
+	return r
+}
 
-		found, err := callHandlers(ctx, bot, current.handlers.purchasedPaidMedia.handlers, update, data)
+// Add outer middleware(s) to CallbackQuery update. See handlers.Middleware
+func (r *Router) OuterMiddlewareCallbackQuery(middlewares ...Middleware[*goram.CallbackQuery]) *Router {
+	r.handlers.callbackQuery.outer = append(r.handlers.callbackQuery.outer, middlewares...)
+	return r
+}
 
-		if err != nil || found {
-			return found, err
-		}
+// Add inner middleware(s) to ShippingQuery update. See handlers.Middleware
+func (r *Router) MiddlewareShippingQuery(middlewares ...Middleware[*goram.ShippingQuery]) *Router {
+	r.handlers.shippingQuery.inner = append(r.handlers.shippingQuery.inner, middlewares...)
+	return r
+}
 
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
+// Add outer middleware(s) to ShippingQuery update. See handlers.Middleware
+func (r *Router) OuterMiddlewareShippingQuery(middlewares ...Middleware[*goram.ShippingQuery]) *Router {
+	r.handlers.shippingQuery.outer = append(r.handlers.shippingQuery.outer, middlewares...)
+	return r
+}
 
-	return false, nil
+// Add inner middleware(s) to PreCheckoutQuery update. See handlers.Middleware
+func (r *Router) MiddlewarePreCheckoutQuery(middlewares ...Middleware[*goram.PreCheckoutQuery]) *Router {
+	r.handlers.preCheckoutQuery.inner = append(r.handlers.preCheckoutQuery.inner, middlewares...)
+	return r
 }
 
-func (r *Router) callPollHandlers(ctx context.Context, bot *goram.Bot, update *goram.Poll, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
+// Add outer middleware(s) to PreCheckoutQuery update. See handlers.Middleware
+func (r *Router) OuterMiddlewarePreCheckoutQuery(middlewares ...Middleware[*goram.PreCheckoutQuery]) *Router {
+	r.handlers.preCheckoutQuery.outer = append(r.handlers.preCheckoutQuery.outer, middlewares...)
+	return r
+}
 
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
+// Add inner middleware(s) to PurchasedPaidMedia update. See handlers.Middleware
+func (r *Router) MiddlewarePurchasedPaidMedia(middlewares ...Middleware[*goram.PaidMediaPurchased]) *Router {
+	r.handlers.purchasedPaidMedia.inner = append(r.handlers.purchasedPaidMedia.inner, middlewares...)
+	return r
+}
 
-		for _, filter := range current.handlers.poll.filters {
-			ok, err := filter(ctx, bot, update, data)
+// Add outer middleware(s) to PurchasedPaidMedia update. See handlers.Middleware
+func (r *Router) OuterMiddlewarePurchasedPaidMedia(middlewares ...Middleware[*goram.PaidMediaPurchased]) *Router {
+	r.handlers.purchasedPaidMedia.outer = append(r.handlers.purchasedPaidMedia.outer, middlewares...)
+	return r
+}
 
-			if err != nil {
-				return ok, err
-			}
+// Add inner middleware(s) to Poll update. See handlers.Middleware
+func (r *Router) MiddlewarePoll(middlewares ...Middleware[*goram.Poll]) *Router {
+	r.handlers.poll.inner = append(r.handlers.poll.inner, middlewares...)
+	return r
+}
 
-			if !ok {
-				continue queueLoop
-			}
-		}
+// Add outer middleware(s) to Poll update. See handlers.Middleware
+func (r *Router) OuterMiddlewarePoll(middlewares ...Middleware[*goram.Poll]) *Router {
+	r.handlers.poll.outer = append(r.handlers.poll.outer, middlewares...)
+	return r
+}
 
-		found, err := callHandlers(ctx, bot, current.handlers.poll.handlers, update, data)
+// Add inner middleware(s) to PollAnswer update. See handlers.Middleware
+func (r *Router) MiddlewarePollAnswer(middlewares ...Middleware[*goram.PollAnswer]) *Router {
+	r.handlers.pollAnswer.inner = append(r.handlers.pollAnswer.inner, middlewares...)
+	return r
+}
 
-		if err != nil || found {
-			return found, err
-		}
+// Add outer middleware(s) to PollAnswer update. See handlers.Middleware
+func (r *Router) OuterMiddlewarePollAnswer(middlewares ...Middleware[*goram.PollAnswer]) *Router {
+	r.handlers.pollAnswer.outer = append(r.handlers.pollAnswer.outer, middlewares...)
+	return r
+}
 
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
+// Add inner middleware(s) to MyChatMember update. See handlers.Middleware
+func (r *Router) MiddlewareMyChatMember(middlewares ...Middleware[*goram.ChatMemberUpdated]) *Router {
+	r.handlers.myChatMember.inner = append(r.handlers.myChatMember.inner, middlewares...)
+	return r
+}
 
-	return false, nil
+// Add outer middleware(s) to MyChatMember update. See handlers.Middleware
+func (r *Router) OuterMiddlewareMyChatMember(middlewares ...Middleware[*goram.ChatMemberUpdated]) *Router {
+	r.handlers.myChatMember.outer = append(r.handlers.myChatMember.outer, middlewares...)
+	return r
 }
 
-func (r *Router) callPollAnswerHandlers(ctx context.Context, bot *goram.Bot, update *goram.PollAnswer, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
+// Add inner middleware(s) to ChatMember update. See handlers.Middleware
+func (r *Router) MiddlewareChatMember(middlewares ...Middleware[*goram.ChatMemberUpdated]) *Router {
+	r.handlers.chatMember.inner = append(r.handlers.chatMember.inner, middlewares...)
+	return r
+}
 
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
+// Add outer middleware(s) to ChatMember update. See handlers.Middleware
+func (r *Router) OuterMiddlewareChatMember(middlewares ...Middleware[*goram.ChatMemberUpdated]) *Router {
+	r.handlers.chatMember.outer = append(r.handlers.chatMember.outer, middlewares...)
+	return r
+}
 
-		for _, filter := range current.handlers.pollAnswer.filters {
-			ok, err := filter(ctx, bot, update, data)
+// Add inner middleware(s) to ChatJoinRequest update. See handlers.Middleware
+func (r *Router) MiddlewareChatJoinRequest(middlewares ...Middleware[*goram.ChatJoinRequest]) *Router {
+	r.handlers.chatJoinRequest.inner = append(r.handlers.chatJoinRequest.inner, middlewares...)
+	return r
+}
 
-			if err != nil {
-				return ok, err
-			}
+// Add outer middleware(s) to ChatJoinRequest update. See handlers.Middleware
+func (r *Router) OuterMiddlewareChatJoinRequest(middlewares ...Middleware[*goram.ChatJoinRequest]) *Router {
+	r.handlers.chatJoinRequest.outer = append(r.handlers.chatJoinRequest.outer, middlewares...)
+	return r
+}
 
-			if !ok {
-				continue queueLoop
-			}
-		}
+// Add inner middleware(s) to ChatBoost update. See handlers.Middleware
+func (r *Router) MiddlewareChatBoost(middlewares ...Middleware[*goram.ChatBoostUpdated]) *Router {
+	r.handlers.chatBoost.inner = append(r.handlers.chatBoost.inner, middlewares...)
+	return r
+}
 
-		found, err := callHandlers(ctx, bot, current.handlers.pollAnswer.handlers, update, data)
+// Add outer middleware(s) to ChatBoost update. See handlers.Middleware
+func (r *Router) OuterMiddlewareChatBoost(middlewares ...Middleware[*goram.ChatBoostUpdated]) *Router {
+	r.handlers.chatBoost.outer = append(r.handlers.chatBoost.outer, middlewares...)
+	return r
+}
 
-		if err != nil || found {
-			return found, err
-		}
+// Add inner middleware(s) to RemovedChatBoost update. See handlers.Middleware
+func (r *Router) MiddlewareRemovedChatBoost(middlewares ...Middleware[*goram.ChatBoostRemoved]) *Router {
+	r.handlers.removedChatBoost.inner = append(r.handlers.removedChatBoost.inner, middlewares...)
+	return r
+}
 
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
+// Add outer middleware(s) to RemovedChatBoost update. See handlers.Middleware
+func (r *Router) OuterMiddlewareRemovedChatBoost(middlewares ...Middleware[*goram.ChatBoostRemoved]) *Router {
+	r.handlers.removedChatBoost.outer = append(r.handlers.removedChatBoost.outer, middlewares...)
+	return r
+}
 
-	return false, nil
+func (r *Router) callMessageHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getMessageHandlers, update.Message, update, data)
 }
 
-func (r *Router) callMyChatMemberHandlers(ctx context.Context, bot *goram.Bot, update *goram.ChatMemberUpdated, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
+func getMessageHandlers(r *Router) *routerHandlers[*goram.Message] {
+	return &r.handlers.message
+}
 
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
+func (r *Router) callEditedMessageHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getEditedMessageHandlers, update.EditedMessage, update, data)
+}
 
-		for _, filter := range current.handlers.myChatMember.filters {
-			ok, err := filter(ctx, bot, update, data)
+func getEditedMessageHandlers(r *Router) *routerHandlers[*goram.Message] {
+	return &r.handlers.editedMessage
+}
 
-			if err != nil {
-				return ok, err
-			}
+func (r *Router) callChannelPostHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getChannelPostHandlers, update.ChannelPost, update, data)
+}
 
-			if !ok {
-				continue queueLoop
-			}
-		}
+func getChannelPostHandlers(r *Router) *routerHandlers[*goram.Message] {
+	return &r.handlers.channelPost
+}
 
-		found, err := callHandlers(ctx, bot, current.handlers.myChatMember.handlers, update, data)
+func (r *Router) callEditedChannelPostHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getEditedChannelPostHandlers, update.EditedChannelPost, update, data)
+}
 
-		if err != nil || found {
-			return found, err
-		}
+func getEditedChannelPostHandlers(r *Router) *routerHandlers[*goram.Message] {
+	return &r.handlers.editedChannelPost
+}
 
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
+func (r *Router) callBusinessConnectionHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getBusinessConnectionHandlers, update.BusinessConnection, update, data)
+}
 
-	return false, nil
+func getBusinessConnectionHandlers(r *Router) *routerHandlers[*goram.BusinessConnection] {
+	return &r.handlers.businessConnection
 }
 
-func (r *Router) callChatMemberHandlers(ctx context.Context, bot *goram.Bot, update *goram.ChatMemberUpdated, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
+func (r *Router) callBusinessMessageHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getBusinessMessageHandlers, update.BusinessMessage, update, data)
+}
 
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
+func getBusinessMessageHandlers(r *Router) *routerHandlers[*goram.Message] {
+	return &r.handlers.businessMessage
+}
 
-		for _, filter := range current.handlers.chatMember.filters {
-			ok, err := filter(ctx, bot, update, data)
+func (r *Router) callEditedBusinessMessageHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getEditedBusinessMessageHandlers, update.EditedBusinessMessage, update, data)
+}
 
-			if err != nil {
-				return ok, err
-			}
+func getEditedBusinessMessageHandlers(r *Router) *routerHandlers[*goram.Message] {
+	return &r.handlers.editedBusinessMessage
+}
 
-			if !ok {
-				continue queueLoop
-			}
-		}
+func (r *Router) callDeletedBusinessMessagesHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getDeletedBusinessMessagesHandlers, update.DeletedBusinessMessages, update, data)
+}
 
-		found, err := callHandlers(ctx, bot, current.handlers.chatMember.handlers, update, data)
+func getDeletedBusinessMessagesHandlers(r *Router) *routerHandlers[*goram.BusinessMessagesDeleted] {
+	return &r.handlers.deletedBusinessMessages
+}
 
-		if err != nil || found {
-			return found, err
-		}
+func (r *Router) callMessageReactionHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getMessageReactionHandlers, update.MessageReaction, update, data)
+}
 
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
+func getMessageReactionHandlers(r *Router) *routerHandlers[*goram.MessageReactionUpdated] {
+	return &r.handlers.messageReaction
+}
 
-	return false, nil
+func (r *Router) callMessageReactionCountHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getMessageReactionCountHandlers, update.MessageReactionCount, update, data)
 }
 
-func (r *Router) callChatJoinRequestHandlers(ctx context.Context, bot *goram.Bot, update *goram.ChatJoinRequest, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
+func getMessageReactionCountHandlers(r *Router) *routerHandlers[*goram.MessageReactionCountUpdated] {
+	return &r.handlers.messageReactionCount
+}
 
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
+func (r *Router) callInlineQueryHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getInlineQueryHandlers, update.InlineQuery, update, data)
+}
 
-		for _, filter := range current.handlers.chatJoinRequest.filters {
-			ok, err := filter(ctx, bot, update, data)
+func getInlineQueryHandlers(r *Router) *routerHandlers[*goram.InlineQuery] {
+	return &r.handlers.inlineQuery
+}
 
-			if err != nil {
-				return ok, err
-			}
+func (r *Router) callChosenInlineResultHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getChosenInlineResultHandlers, update.ChosenInlineResult, update, data)
+}
 
-			if !ok {
-				continue queueLoop
-			}
-		}
+func getChosenInlineResultHandlers(r *Router) *routerHandlers[*goram.ChosenInlineResult] {
+	return &r.handlers.chosenInlineResult
+}
 
-		found, err := callHandlers(ctx, bot, current.handlers.chatJoinRequest.handlers, update, data)
+func (r *Router) callCallbackQueryHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getCallbackQueryHandlers, update.CallbackQuery, update, data)
+}
 
-		if err != nil || found {
-			return found, err
-		}
+func getCallbackQueryHandlers(r *Router) *routerHandlers[*goram.CallbackQuery] {
+	return &r.handlers.callbackQuery
+}
 
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
+func (r *Router) callShippingQueryHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getShippingQueryHandlers, update.ShippingQuery, update, data)
+}
 
-	return false, nil
+func getShippingQueryHandlers(r *Router) *routerHandlers[*goram.ShippingQuery] {
+	return &r.handlers.shippingQuery
 }
 
-func (r *Router) callChatBoostHandlers(ctx context.Context, bot *goram.Bot, update *goram.ChatBoostUpdated, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
+func (r *Router) callPreCheckoutQueryHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getPreCheckoutQueryHandlers, update.PreCheckoutQuery, update, data)
+}
 
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
+func getPreCheckoutQueryHandlers(r *Router) *routerHandlers[*goram.PreCheckoutQuery] {
+	return &r.handlers.preCheckoutQuery
+}
 
-		for _, filter := range current.handlers.chatBoost.filters {
-			ok, err := filter(ctx, bot, update, data)
+func (r *Router) callPurchasedPaidMediaHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getPurchasedPaidMediaHandlers, update.PurchasedPaidMedia, update, data)
+}
 
-			if err != nil {
-				return ok, err
-			}
+func getPurchasedPaidMediaHandlers(r *Router) *routerHandlers[*goram.PaidMediaPurchased] {
+	return &r.handlers.purchasedPaidMedia
+}
 
-			if !ok {
-				continue queueLoop
-			}
-		}
+func (r *Router) callPollHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getPollHandlers, update.Poll, update, data)
+}
 
-		found, err := callHandlers(ctx, bot, current.handlers.chatBoost.handlers, update, data)
+func getPollHandlers(r *Router) *routerHandlers[*goram.Poll] {
+	return &r.handlers.poll
+}
 
-		if err != nil || found {
-			return found, err
-		}
+func (r *Router) callPollAnswerHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getPollAnswerHandlers, update.PollAnswer, update, data)
+}
 
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
+func getPollAnswerHandlers(r *Router) *routerHandlers[*goram.PollAnswer] {
+	return &r.handlers.pollAnswer
+}
 
-	return false, nil
+func (r *Router) callMyChatMemberHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getMyChatMemberHandlers, update.MyChatMember, update, data)
 }
 
-func (r *Router) callRemovedChatBoostHandlers(ctx context.Context, bot *goram.Bot, update *goram.ChatBoostRemoved, data Data) (bool, error) {
-	queue := make([]*Router, 0, len(r.children)+1)
-	queue = append(queue, r)
+func getMyChatMemberHandlers(r *Router) *routerHandlers[*goram.ChatMemberUpdated] {
+	return &r.handlers.myChatMember
+}
 
-queueLoop:
-	for len(queue) > 0 {
-		current := queue[0]
-		queue = queue[1:]
+func (r *Router) callChatMemberHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getChatMemberHandlers, update.ChatMember, update, data)
+}
 
-		for _, filter := range current.handlers.removedChatBoost.filters {
-			ok, err := filter(ctx, bot, update, data)
+func getChatMemberHandlers(r *Router) *routerHandlers[*goram.ChatMemberUpdated] {
+	return &r.handlers.chatMember
+}
 
-			if err != nil {
-				return ok, err
-			}
+func (r *Router) callChatJoinRequestHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getChatJoinRequestHandlers, update.ChatJoinRequest, update, data)
+}
 
-			if !ok {
-				continue queueLoop
-			}
-		}
+func getChatJoinRequestHandlers(r *Router) *routerHandlers[*goram.ChatJoinRequest] {
+	return &r.handlers.chatJoinRequest
+}
 
-		found, err := callHandlers(ctx, bot, current.handlers.removedChatBoost.handlers, update, data)
+func (r *Router) callChatBoostHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getChatBoostHandlers, update.ChatBoost, update, data)
+}
 
-		if err != nil || found {
-			return found, err
-		}
+func getChatBoostHandlers(r *Router) *routerHandlers[*goram.ChatBoostUpdated] {
+	return &r.handlers.chatBoost
+}
 
-		if len(current.children) > 0 {
-			queue = append(queue, current.children...)
-		}
-	}
+func (r *Router) callRemovedChatBoostHandlers(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+	return feedRouter(ctx, bot, r, getRemovedChatBoostHandlers, update.RemovedChatBoost, update, data)
+}
 
-	return false, nil
+func getRemovedChatBoostHandlers(r *Router) *routerHandlers[*goram.ChatBoostRemoved] {
+	return &r.handlers.removedChatBoost
 }
 
-// See Router.FeedUpdates()
-func (r *Router) FeedUpdate(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
+func (r *Router) feedUpdate(ctx context.Context, bot *goram.Bot, update *goram.Update, data Data) (bool, error) {
 	if update.Message != nil {
-		return r.callMessageHandlers(ctx, bot, update.Message, data)
+		return r.callMessageHandlers(ctx, bot, update, data)
 	}
 
 	if update.EditedMessage != nil {
-		return r.callEditedMessageHandlers(ctx, bot, update.EditedMessage, data)
+		return r.callEditedMessageHandlers(ctx, bot, update, data)
 	}
 
 	if update.ChannelPost != nil {
-		return r.callChannelPostHandlers(ctx, bot, update.ChannelPost, data)
+		return r.callChannelPostHandlers(ctx, bot, update, data)
 	}
 
 	if update.EditedChannelPost != nil {
-		return r.callEditedChannelPostHandlers(ctx, bot, update.EditedChannelPost, data)
+		return r.callEditedChannelPostHandlers(ctx, bot, update, data)
 	}
 
 	if update.BusinessConnection != nil {
-		return r.callBusinessConnectionHandlers(ctx, bot, update.BusinessConnection, data)
+		return r.callBusinessConnectionHandlers(ctx, bot, update, data)
 	}
 
 	if update.BusinessMessage != nil {
-		return r.callBusinessMessageHandlers(ctx, bot, update.BusinessMessage, data)
+		return r.callBusinessMessageHandlers(ctx, bot, update, data)
 	}
 
 	if update.EditedBusinessMessage != nil {
-		return r.callEditedBusinessMessageHandlers(ctx, bot, update.EditedBusinessMessage, data)
+		return r.callEditedBusinessMessageHandlers(ctx, bot, update, data)
 	}
 
 	if update.DeletedBusinessMessages != nil {
-		return r.callDeletedBusinessMessagesHandlers(ctx, bot, update.DeletedBusinessMessages, data)
+		return r.callDeletedBusinessMessagesHandlers(ctx, bot, update, data)
 	}
 
 	if update.MessageReaction != nil {
-		return r.callMessageReactionHandlers(ctx, bot, update.MessageReaction, data)
+		return r.callMessageReactionHandlers(ctx, bot, update, data)
 	}
 
 	if update.MessageReactionCount != nil {
-		return r.callMessageReactionCountHandlers(ctx, bot, update.MessageReactionCount, data)
+		return r.callMessageReactionCountHandlers(ctx, bot, update, data)
 	}
 
 	if update.InlineQuery != nil {
-		return r.callInlineQueryHandlers(ctx, bot, update.InlineQuery, data)
+		return r.callInlineQueryHandlers(ctx, bot, update, data)
 	}
 
 	if update.ChosenInlineResult != nil {
-		return r.callChosenInlineResultHandlers(ctx, bot, update.ChosenInlineResult, data)
+		return r.callChosenInlineResultHandlers(ctx, bot, update, data)
 	}
 
 	if update.CallbackQuery != nil {
-		return r.callCallbackQueryHandlers(ctx, bot, update.CallbackQuery, data)
+		return r.callCallbackQueryHandlers(ctx, bot, update, data)
 	}
 
 	if update.ShippingQuery != nil {
-		return r.callShippingQueryHandlers(ctx, bot, update.ShippingQuery, data)
+		return r.callShippingQueryHandlers(ctx, bot, update, data)
 	}
 
 	if update.PreCheckoutQuery != nil {
-		return r.callPreCheckoutQueryHandlers(ctx, bot, update.PreCheckoutQuery, data)
+		return r.callPreCheckoutQueryHandlers(ctx, bot, update, data)
 	}
 
 	if update.PurchasedPaidMedia != nil {
-		return r.callPurchasedPaidMediaHandlers(ctx, bot, update.PurchasedPaidMedia, data)
+		return r.callPurchasedPaidMediaHandlers(ctx, bot, update, data)
 	}
 
 	if update.Poll != nil {
-		return r.callPollHandlers(ctx, bot, update.Poll, data)
+		return r.callPollHandlers(ctx, bot, update, data)
 	}
 
 	if update.PollAnswer != nil {
-		return r.callPollAnswerHandlers(ctx, bot, update.PollAnswer, data)
+		return r.callPollAnswerHandlers(ctx, bot, update, data)
 	}
 
 	if update.MyChatMember != nil {
-		return r.callMyChatMemberHandlers(ctx, bot, update.MyChatMember, data)
+		return r.callMyChatMemberHandlers(ctx, bot, update, data)
 	}
 
 	if update.ChatMember != nil {
-		return r.callChatMemberHandlers(ctx, bot, update.ChatMember, data)
+		return r.callChatMemberHandlers(ctx, bot, update, data)
 	}
 
 	if update.ChatJoinRequest != nil {
-		return r.callChatJoinRequestHandlers(ctx, bot, update.ChatJoinRequest, data)
+		return r.callChatJoinRequestHandlers(ctx, bot, update, data)
 	}
 
 	if update.ChatBoost != nil {
-		return r.callChatBoostHandlers(ctx, bot, update.ChatBoost, data)
+		return r.callChatBoostHandlers(ctx, bot, update, data)
 	}
 
 	if update.RemovedChatBoost != nil {
-		return r.callRemovedChatBoostHandlers(ctx, bot, update.RemovedChatBoost, data)
+		return r.callRemovedChatBoostHandlers(ctx, bot, update, data)
 	}
 
 	return false, nil
 }
 
+// Returns update types that have handlers or middlewares in the router or any of its descendants,
+// and update types marked with .UseUpdateTypes().
+// Use it as allowed updates for getUpdates or setWebhook.
 func (r *Router) GetUsedUpdateTypes() []goram.UpdateType {
 	used := []goram.UpdateType{}
-	if len(r.handlers.message.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.message.used() || len(r.mediaGroupHandlers) > 0 || r.usesType(goram.UpdateMessage)
+	}) {
 		used = append(used, goram.UpdateMessage)
 	}
-	if len(r.handlers.editedMessage.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool { return r.handlers.editedMessage.used() || r.usesType(goram.UpdateEditedMessage) }) {
 		used = append(used, goram.UpdateEditedMessage)
 	}
-	if len(r.handlers.channelPost.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool { return r.handlers.channelPost.used() || r.usesType(goram.UpdateChannelPost) }) {
 		used = append(used, goram.UpdateChannelPost)
 	}
-	if len(r.handlers.editedChannelPost.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.editedChannelPost.used() || r.usesType(goram.UpdateEditedChannelPost)
+	}) {
 		used = append(used, goram.UpdateEditedChannelPost)
 	}
-	if len(r.handlers.businessConnection.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.businessConnection.used() || r.usesType(goram.UpdateBusinessConnection)
+	}) {
 		used = append(used, goram.UpdateBusinessConnection)
 	}
-	if len(r.handlers.businessMessage.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.businessMessage.used() || r.usesType(goram.UpdateBusinessMessage)
+	}) {
 		used = append(used, goram.UpdateBusinessMessage)
 	}
-	if len(r.handlers.editedBusinessMessage.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.editedBusinessMessage.used() || r.usesType(goram.UpdateEditedBusinessMessage)
+	}) {
 		used = append(used, goram.UpdateEditedBusinessMessage)
 	}
-	if len(r.handlers.deletedBusinessMessages.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.deletedBusinessMessages.used() || r.usesType(goram.UpdateDeletedBusinessMessages)
+	}) {
 		used = append(used, goram.UpdateDeletedBusinessMessages)
 	}
-	if len(r.handlers.messageReaction.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.messageReaction.used() || r.usesType(goram.UpdateMessageReaction)
+	}) {
 		used = append(used, goram.UpdateMessageReaction)
 	}
-	if len(r.handlers.messageReactionCount.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.messageReactionCount.used() || r.usesType(goram.UpdateMessageReactionCount)
+	}) {
 		used = append(used, goram.UpdateMessageReactionCount)
 	}
-	if len(r.handlers.inlineQuery.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool { return r.handlers.inlineQuery.used() || r.usesType(goram.UpdateInlineQuery) }) {
 		used = append(used, goram.UpdateInlineQuery)
 	}
-	if len(r.handlers.chosenInlineResult.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.chosenInlineResult.used() || r.usesType(goram.UpdateChosenInlineResult)
+	}) {
 		used = append(used, goram.UpdateChosenInlineResult)
 	}
-	if len(r.handlers.callbackQuery.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool { return r.handlers.callbackQuery.used() || r.usesType(goram.UpdateCallbackQuery) }) {
 		used = append(used, goram.UpdateCallbackQuery)
 	}
-	if len(r.handlers.shippingQuery.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool { return r.handlers.shippingQuery.used() || r.usesType(goram.UpdateShippingQuery) }) {
 		used = append(used, goram.UpdateShippingQuery)
 	}
-	if len(r.handlers.preCheckoutQuery.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.preCheckoutQuery.used() || r.usesType(goram.UpdatePreCheckoutQuery)
+	}) {
 		used = append(used, goram.UpdatePreCheckoutQuery)
 	}
-	if len(r.handlers.purchasedPaidMedia.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.purchasedPaidMedia.used() || r.usesType(goram.UpdatePurchasedPaidMedia)
+	}) {
 		used = append(used, goram.UpdatePurchasedPaidMedia)
 	}
-	if len(r.handlers.poll.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool { return r.handlers.poll.used() || r.usesType(goram.UpdatePoll) }) {
 		used = append(used, goram.UpdatePoll)
 	}
-	if len(r.handlers.pollAnswer.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool { return r.handlers.pollAnswer.used() || r.usesType(goram.UpdatePollAnswer) }) {
 		used = append(used, goram.UpdatePollAnswer)
 	}
-	if len(r.handlers.myChatMember.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool { return r.handlers.myChatMember.used() || r.usesType(goram.UpdateMyChatMember) }) {
 		used = append(used, goram.UpdateMyChatMember)
 	}
-	if len(r.handlers.chatMember.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool { return r.handlers.chatMember.used() || r.usesType(goram.UpdateChatMember) }) {
 		used = append(used, goram.UpdateChatMember)
 	}
-	if len(r.handlers.chatJoinRequest.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.chatJoinRequest.used() || r.usesType(goram.UpdateChatJoinRequest)
+	}) {
 		used = append(used, goram.UpdateChatJoinRequest)
 	}
-	if len(r.handlers.chatBoost.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool { return r.handlers.chatBoost.used() || r.usesType(goram.UpdateChatBoost) }) {
 		used = append(used, goram.UpdateChatBoost)
 	}
-	if len(r.handlers.removedChatBoost.handlers) > 0 {
+	if r.anyRouter(func(r *Router) bool {
+		return r.handlers.removedChatBoost.used() || r.usesType(goram.UpdateRemovedChatBoost)
+	}) {
 		used = append(used, goram.UpdateRemovedChatBoost)
 	}
 	return used
 }
+
+// Returns amount of handlers per update type of this router only. Update types without handlers are omitted.
+func (r *Router) handlerCounts() map[goram.UpdateType]int {
+	counts := map[goram.UpdateType]int{}
+	if n := len(r.handlers.message.handlers); n > 0 {
+		counts[goram.UpdateMessage] = n
+	}
+	if n := len(r.handlers.editedMessage.handlers); n > 0 {
+		counts[goram.UpdateEditedMessage] = n
+	}
+	if n := len(r.handlers.channelPost.handlers); n > 0 {
+		counts[goram.UpdateChannelPost] = n
+	}
+	if n := len(r.handlers.editedChannelPost.handlers); n > 0 {
+		counts[goram.UpdateEditedChannelPost] = n
+	}
+	if n := len(r.handlers.businessConnection.handlers); n > 0 {
+		counts[goram.UpdateBusinessConnection] = n
+	}
+	if n := len(r.handlers.businessMessage.handlers); n > 0 {
+		counts[goram.UpdateBusinessMessage] = n
+	}
+	if n := len(r.handlers.editedBusinessMessage.handlers); n > 0 {
+		counts[goram.UpdateEditedBusinessMessage] = n
+	}
+	if n := len(r.handlers.deletedBusinessMessages.handlers); n > 0 {
+		counts[goram.UpdateDeletedBusinessMessages] = n
+	}
+	if n := len(r.handlers.messageReaction.handlers); n > 0 {
+		counts[goram.UpdateMessageReaction] = n
+	}
+	if n := len(r.handlers.messageReactionCount.handlers); n > 0 {
+		counts[goram.UpdateMessageReactionCount] = n
+	}
+	if n := len(r.handlers.inlineQuery.handlers); n > 0 {
+		counts[goram.UpdateInlineQuery] = n
+	}
+	if n := len(r.handlers.chosenInlineResult.handlers); n > 0 {
+		counts[goram.UpdateChosenInlineResult] = n
+	}
+	if n := len(r.handlers.callbackQuery.handlers); n > 0 {
+		counts[goram.UpdateCallbackQuery] = n
+	}
+	if n := len(r.handlers.shippingQuery.handlers); n > 0 {
+		counts[goram.UpdateShippingQuery] = n
+	}
+	if n := len(r.handlers.preCheckoutQuery.handlers); n > 0 {
+		counts[goram.UpdatePreCheckoutQuery] = n
+	}
+	if n := len(r.handlers.purchasedPaidMedia.handlers); n > 0 {
+		counts[goram.UpdatePurchasedPaidMedia] = n
+	}
+	if n := len(r.handlers.poll.handlers); n > 0 {
+		counts[goram.UpdatePoll] = n
+	}
+	if n := len(r.handlers.pollAnswer.handlers); n > 0 {
+		counts[goram.UpdatePollAnswer] = n
+	}
+	if n := len(r.handlers.myChatMember.handlers); n > 0 {
+		counts[goram.UpdateMyChatMember] = n
+	}
+	if n := len(r.handlers.chatMember.handlers); n > 0 {
+		counts[goram.UpdateChatMember] = n
+	}
+	if n := len(r.handlers.chatJoinRequest.handlers); n > 0 {
+		counts[goram.UpdateChatJoinRequest] = n
+	}
+	if n := len(r.handlers.chatBoost.handlers); n > 0 {
+		counts[goram.UpdateChatBoost] = n
+	}
+	if n := len(r.handlers.removedChatBoost.handlers); n > 0 {
+		counts[goram.UpdateRemovedChatBoost] = n
+	}
+	return counts
+}
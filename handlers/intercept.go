@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"slices"
+
+	"github.com/TrixiS/goram"
+)
+
+// Gets a chance to consume an update before it is routed.
+// Returns true if the update is consumed, such updates are not passed to handlers.
+//
+// Interceptors are called from .FeedUpdates() and .FeedUpdate() of the root router and from
+// handlers.Dispatcher before an update is queued, so they must not block.
+// Interceptors do not count in .GetUsedUpdateTypes(), mark update types they need with .UseUpdateTypes().
+// See conversation.Manager for an example.
+type InterceptFunc func(ctx context.Context, bot *goram.Bot, update *goram.Update) bool
+
+// Add interceptor(s) to the router. Only used by the root router.
+func (r *Router) Intercept(interceptors ...InterceptFunc) *Router {
+	r.interceptors = append(r.interceptors, interceptors...)
+	return r
+}
+
+// Marks update types as used, so .GetUsedUpdateTypes() returns them even if there are no handlers for them.
+// Useful for update types that are only handled by interceptors.
+func (r *Router) UseUpdateTypes(types ...goram.UpdateType) *Router {
+	r.usedTypes = append(r.usedTypes, types...)
+	return r
+}
+
+func (r *Router) usesType(t goram.UpdateType) bool {
+	return slices.Contains(r.usedTypes, t)
+}
+
+func (r *Router) intercept(ctx context.Context, bot *goram.Bot, update *goram.Update) bool {
+	for _, interceptor := range r.interceptors {
+		if interceptor(ctx, bot, update) {
+			return true
+		}
+	}
+
+	return false
+}
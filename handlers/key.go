@@ -0,0 +1,49 @@
+package handlers
+
+// Typed handlers.Data key. Values are stored in data under the key name,
+// so keys with the same name and type are interchangeable.
+//
+//	var UserKey = handlers.NewKey[*User]("user")
+//
+//	UserKey.Set(data, user)
+//	user, ok := UserKey.Get(data)
+type Key[T any] struct {
+	name string
+}
+
+func NewKey[T any](name string) Key[T] {
+	return Key[T]{name: name}
+}
+
+func (k Key[T]) Name() string {
+	return k.name
+}
+
+// Returns the value stored under the key. Returns false if there is no value or it has a different type.
+func (k Key[T]) Get(data Data) (T, bool) {
+	value, ok := data[k.name].(T)
+	return value, ok
+}
+
+// Same as .Get() but panics if there is no value.
+func (k Key[T]) MustGet(data Data) T {
+	value, ok := k.Get(data)
+
+	if !ok {
+		panic("handlers: no " + k.name + " in handler data")
+	}
+
+	return value
+}
+
+// Stores the value under the key. Does nothing if data is nil.
+func (k Key[T]) Set(data Data, value T) {
+	if data != nil {
+		data[k.name] = value
+	}
+}
+
+// Removes the value from data.
+func (k Key[T]) Delete(data Data) {
+	delete(data, k.name)
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/TrixiS/goram"
+)
+
+// Media group (album) handler function. Messages are sorted by message id.
+type MediaGroupFunc func(ctx context.Context, bot *goram.Bot, messages []*goram.Message, data Data) error
+
+// Gets called if a media group collected in background (see RouterOptions.MediaGroupWait) is handled with an error.
+type MediaGroupErrorFunc func(ctx context.Context, bot *goram.Bot, messages []*goram.Message, err error)
+
+type mediaGroupHandler struct {
+	cb      MediaGroupFunc
+	filters []Filter[*goram.Message]
+}
+
+type pendingMediaGroup struct {
+	ctx      context.Context
+	bot      *goram.Bot
+	data     Data
+	messages []*goram.Message
+	timer    *time.Timer
+}
+
+type mediaGroupCollector struct {
+	mu     sync.Mutex
+	groups map[string]*pendingMediaGroup // key is chat id + media group id
+}
+
+// Add media group handler with provided filters. Filters are applied to the first message of a group.
+//
+// Albums pass through router message filters and outer middlewares (router-wide and .OuterMiddlewareMessage())
+// with the first message of the album. Router-wide inner middlewares get []*goram.Message,
+// inner middlewares of message update (.MiddlewareMessage()) are not applied.
+//
+// Once a router (or any of its children) has a media group handler, messages with Message.MediaGroupID
+// are collected into albums and passed to media group handlers.
+// If no media group handler matches an album, its messages are passed to message handlers one by one.
+//
+// See RouterOptions.MediaGroupWait.
+func (r *Router) MediaGroup(handlerFunc MediaGroupFunc, filters ...Filter[*goram.Message]) *Router {
+	h := mediaGroupHandler{
+		cb:      handlerFunc,
+		filters: filters,
+	}
+
+	r.mediaGroupHandlers = append(r.mediaGroupHandlers, h)
+	return r
+}
+
+// Immediately handles all media groups that are being collected in background.
+// Call it before shutdown so that pending albums are not lost.
+func (r *Router) FlushMediaGroups() {
+	r.mediaGroups.mu.Lock()
+	groups := make([]*pendingMediaGroup, 0, len(r.mediaGroups.groups))
+
+	for key, group := range r.mediaGroups.groups {
+		// if the timer has already fired, the group is going to be handled by the timer func
+		if group.timer.Stop() {
+			delete(r.mediaGroups.groups, key)
+			groups = append(groups, group)
+		}
+	}
+
+	r.mediaGroups.mu.Unlock()
+
+	for _, group := range groups {
+		r.handlePendingMediaGroup(group)
+	}
+}
+
+func (r *Router) hasMediaGroupHandlers() bool {
+	if len(r.mediaGroupHandlers) > 0 {
+		return true
+	}
+
+	for _, child := range r.children {
+		if child.hasMediaGroupHandlers() {
+			return true
+		}
+	}
+
+	return false
+}
+
+func isMediaGroupUpdate(update *goram.Update) bool {
+	return update.Message != nil && update.Message.MediaGroupID != "" && update.Message.Chat != nil
+}
+
+func mediaGroupKey(message *goram.Message) string {
+	return strconv.FormatInt(message.Chat.ID, 10) + ":" + message.MediaGroupID
+}
+
+// Adds the message to a pending media group and (re)starts its timer.
+func (r *Router) collectMediaGroupMessage(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) {
+	key := mediaGroupKey(message)
+
+	r.mediaGroups.mu.Lock()
+	defer r.mediaGroups.mu.Unlock()
+
+	if r.mediaGroups.groups == nil {
+		r.mediaGroups.groups = make(map[string]*pendingMediaGroup)
+	}
+
+	group := r.mediaGroups.groups[key]
+
+	if group != nil {
+		group.messages = append(group.messages, message)
+		group.timer.Reset(r.Options.MediaGroupWait)
+		return
+	}
+
+	group = &pendingMediaGroup{
+		ctx:      context.WithoutCancel(ctx),
+		bot:      bot,
+		data:     data,
+		messages: []*goram.Message{message},
+	}
+
+	group.timer = time.AfterFunc(r.Options.MediaGroupWait, func() {
+		r.mediaGroups.mu.Lock()
+
+		if r.mediaGroups.groups[key] != group {
+			r.mediaGroups.mu.Unlock()
+			return
+		}
+
+		delete(r.mediaGroups.groups, key)
+		r.mediaGroups.mu.Unlock()
+
+		r.handlePendingMediaGroup(group)
+	})
+
+	r.mediaGroups.groups[key] = group
+}
+
+func (r *Router) handlePendingMediaGroup(group *pendingMediaGroup) {
+	_, err := r.handleMediaGroup(group.ctx, group.bot, group.messages, group.data)
+
+	if err != nil && r.Options.OnMediaGroupError != nil {
+		r.Options.OnMediaGroupError(group.ctx, group.bot, group.messages, err)
+	}
+}
+
+// Passes an album to media group handlers. Falls back to message handlers if no media group handler matched.
+func (r *Router) handleMediaGroup(
+	ctx context.Context,
+	bot *goram.Bot,
+	messages []*goram.Message,
+	data Data,
+) (bool, error) {
+	slices.SortFunc(messages, func(a, b *goram.Message) int { return a.MessageID - b.MessageID })
+
+	// data changed by the album filters and middlewares must not leak into the message routing
+	found, err := r.callMediaGroupHandlers(ctx, bot, messages, cloneData(data))
+
+	if err != nil || found {
+		return found, err
+	}
+
+	errs := []error{}
+
+	for _, message := range messages {
+		messageFound, err := r.callMessageHandlers(ctx, bot, &goram.Update{Message: message}, cloneData(data))
+		found = found || messageFound
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return found, errors.Join(errs...)
+}
+
+// Passes an album to media group handlers of the router and its children.
+// The album is routed like a message update, using the first message for filters and outer middlewares.
+func (r *Router) callMediaGroupHandlers(
+	ctx context.Context,
+	bot *goram.Bot,
+	messages []*goram.Message,
+	data Data,
+) (bool, error) {
+	first := messages[0]
+
+	rt := &routing[*goram.Message]{
+		bot:    bot,
+		update: first,
+		raw:    &goram.Update{Message: first},
+		get:    getMessageHandlers,
+	}
+
+	rt.handle = func(n *routeNode[*goram.Message]) (bool, error) {
+		return callMediaGroupHandlers(n, bot, messages)
+	}
+
+	// unmatched albums are passed to message handlers, so fallback handlers run there
+	rt.fallback = func(n *routeNode[*goram.Message]) (bool, error) {
+		return false, nil
+	}
+
+	return rt.run(ctx, r, data)
+}
+
+func callMediaGroupHandlers(n *routeNode[*goram.Message], bot *goram.Bot, messages []*goram.Message) (bool, error) {
+	first := messages[0]
+
+handlersLoop:
+	for _, handler := range n.router.mediaGroupHandlers {
+		for _, filter := range handler.filters {
+			ok, err := filter(n.ctx, bot, first, n.data)
+
+			if err != nil {
+				return false, err
+			}
+
+			if !ok {
+				continue handlersLoop
+			}
+		}
+
+		inner := innerMiddlewares[*goram.Message, []*goram.Message](n, nil)
+		err := chainMiddlewares(Func[[]*goram.Message](handler.cb), inner)(n.ctx, bot, messages, n.data)
+		return true, err
+	}
+
+	return false, nil
+}
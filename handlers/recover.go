@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/TrixiS/goram"
+)
+
+// A panic recovered by a router. See RouterOptions.RecoverPanics.
+type PanicError struct {
+	Value  any           // Value passed to panic()
+	Stack  []byte        // Stack trace of the goroutine at the moment of the panic
+	Update *goram.Update // Update that was being handled. For media groups it contains the first message of the album
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Returns the panic value if it is an error.
+func (e *PanicError) Unwrap() error {
+	err, _ := e.Value.(error)
+	return err
+}
+
+// Wraps handler func so that ctx passed to it is canceled after timeout.
+//
+// Note that the handler is not interrupted, it has to respect ctx cancellation itself.
+// See also RouterOptions.HandlerTimeout.
+func WithTimeout[U any](timeout time.Duration, handlerFunc Func[U]) Func[U] {
+	return func(ctx context.Context, bot *goram.Bot, update U, data Data) error {
+		ctx, cancel := context.WithTimeout(ctx, timeout)
+		defer cancel()
+		return handlerFunc(ctx, bot, update, data)
+	}
+}
+
+func timeoutMiddleware[U any](timeout time.Duration) Middleware[U] {
+	return func(next Func[U]) Func[U] {
+		return WithTimeout(timeout, next)
+	}
+}
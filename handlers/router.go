@@ -2,12 +2,18 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"time"
 
 	"github.com/TrixiS/goram"
 )
 
-// Arbitrary data that is being passed to filters and handlers.
+// Arbitrary data that is being passed to filters and handlers. Use handlers.Key to access it.
 // See cbdata.Filter for an example.
+//
+// Data is per-update: every update gets its own copy, so it is not safe to share it between goroutines.
 type Data map[string]any
 
 // Update handler function.
@@ -16,6 +22,29 @@ type Func[U any] func(ctx context.Context, bot *goram.Bot, update U, data Data)
 // Update handler filter function.
 type Filter[U any] func(ctx context.Context, bot *goram.Bot, update U, data Data) (bool, error)
 
+// Router error handler. It gets called when a handler, filter or middleware of the router
+// (or any of its children) returns an error.
+//
+// Return nil to mark the error as handled, otherwise the returned error is passed
+// to the next error handler and then to the parent router.
+// For media groups update contains the first message of the album.
+type ErrorFunc func(ctx context.Context, bot *goram.Bot, update *goram.Update, err error) error
+
+// Update middleware. It receives the next function in the chain and returns a function that wraps it.
+//
+// A middleware can short-circuit the chain by not calling next,
+// or replace ctx and data passed downstream by calling next with different values.
+//
+// Outer middlewares run before router-level filters, once per router that the update reaches.
+// They wrap only the router and its children: next returns the error of the router subtree
+// that its error handlers did not handle. Since routing is breadth-first, next waits while the routers
+// after the router are routed (with their own ctx and data), so a router with outer middlewares
+// is routed in its own goroutine. Only one goroutine of the routing runs at a time.
+// If an outer middleware does not call next, the router and its children are skipped.
+// Inner middlewares wrap only the matched handler, they run after all filters have passed.
+// Inner middlewares are inherited by children routers.
+type Middleware[U any] func(next Func[U]) Func[U]
+
 type handler[U any] struct {
 	cb      Func[U]
 	filters []Filter[U]
@@ -24,10 +53,47 @@ type handler[U any] struct {
 type routerHandlers[T any] struct {
 	filters  []Filter[T] // router-level filters for this update
 	handlers []handler[T]
+	fallback []handler[T]    // handlers that run if no handler of the router or its children matched
+	outer    []Middleware[T] // router-level outer middlewares for this update
+	inner    []Middleware[T] // router-level inner middlewares for this update
 }
 
+// What Router.FeedUpdates() does when an update of a batch is handled with an error.
+type BatchErrorPolicy int
+
+const (
+	BatchContinue BatchErrorPolicy = iota // Handle the rest of the batch, errors are joined
+	BatchAbort                            // Return the error right away, the rest of the batch is not handled
+)
+
 type RouterOptions struct {
 	Name string // Name of the router. Useful for debugging.
+
+	// Only used by the root router. Default is handlers.BatchContinue
+	BatchErrorPolicy BatchErrorPolicy
+
+	// Panics in handlers, filters and middlewares of the router and its children are recovered
+	// and passed to error handlers as *handlers.PanicError. See Router.OnError()
+	RecoverPanics bool
+
+	// Deadline of ctx passed to handlers of the router and its children (see handlers.WithTimeout).
+	// Inner middlewares are covered too. A child router can only make the timeout shorter
+	HandlerTimeout time.Duration
+
+	// Only used by the root router. Media group messages are collected in background
+	// until no new message of the group arrives for MediaGroupWait.
+	// This is required for webhooks, where every album message comes in a separate request.
+	// If MediaGroupWait is 0, albums are collected within a single .FeedUpdates() batch only.
+	//
+	// Albums collected in background are handled after .FeedUpdates() returns, but their messages are reported
+	// as handled. So an offset saved after .FeedUpdates() (goram.PollerOptions.OffsetStore with
+	// handlers.Dispatcher.FeedWait(), for example) can pass albums that are not handled yet,
+	// and such albums are lost if the process exits before .FlushMediaGroups().
+	// Keep it 0 with long polling, where album messages come in the same batch.
+	MediaGroupWait time.Duration
+
+	// Only used by the root router. Gets called when an album collected in background is handled with an error
+	OnMediaGroupError MediaGroupErrorFunc
 }
 
 type Router struct {
@@ -35,6 +101,15 @@ type Router struct {
 
 	handlers handlers
 	children []*Router
+	outer    []Middleware[any] // outer middlewares for every update type
+	inner    []Middleware[any] // inner middlewares for every update type
+	onError  []ErrorFunc
+
+	interceptors []InterceptFunc
+	usedTypes    []goram.UpdateType // marked with .UseUpdateTypes()
+
+	mediaGroupHandlers []mediaGroupHandler
+	mediaGroups        mediaGroupCollector
 }
 
 // Creates a new router.
@@ -58,30 +133,161 @@ func (r *Router) Group(options RouterOptions) *Router {
 	return child
 }
 
+// Add inner middleware(s) for every update type.
+//
+// The update is passed to the middleware as is, for example *goram.Message for message updates
+// and []*goram.Message for media groups (see .MediaGroup()).
+// Passing an update of a different type to next will panic.
+//
+// Router-wide middlewares run before middlewares added for a specific update type (like .MiddlewareMessage()).
+func (r *Router) Middleware(middlewares ...Middleware[any]) *Router {
+	r.inner = append(r.inner, middlewares...)
+	return r
+}
+
+// Add outer middleware(s) for every update type. See .Middleware().
+func (r *Router) OuterMiddleware(middlewares ...Middleware[any]) *Router {
+	r.outer = append(r.outer, middlewares...)
+	return r
+}
+
+// Add error handler(s) to the router.
+//
+// Error handlers are scoped like filters: errors of the router and its children
+// are passed to the router error handlers first, unhandled errors propagate to the parent router.
+// Once an error is handled, the update is considered handled.
+func (r *Router) OnError(handlers ...ErrorFunc) *Router {
+	r.onError = append(r.onError, handlers...)
+	return r
+}
+
 // It is expected that you pass updates from goram.LongPollUpdates (for example) to the root router
 // using .FeedUpdates() method.
-// Updates fed to a router get passed through top-level filters first,
-// then to router handlers and then to every child top-level filters and handlers (iteratively).
+// Updates fed to a router get passed through outer middlewares and top-level filters first,
+// then to router handlers and then to every child outer middlewares, top-level filters and handlers (breadth-first).
+// Outer middlewares of a router wrap only the router and its children, see handlers.Middleware.
 //
 // If top-level filter returns false, then the update gets passed to the next router (if any).
 // If handler filter returns false, then the update gets passed to the next handler (if any).
+// If no handler matched, the update gets passed to fallback handlers (see .FallbackMessage())
+// of the routers whose filters passed, the deepest routers first.
+//
+// If there are media group handlers (see .MediaGroup()), messages of the same album are passed to them together.
+//
+// Updates consumed by interceptors (see .Intercept()) are not routed and marked as handled.
+// Every update of the batch is handled with its own copy of data, nil data is replaced with an empty one.
+// Returns handled flag for every update and handler errors joined with errors.Join().
+// See RouterOptions.BatchErrorPolicy for what happens after an error.
 func (r *Router) FeedUpdates(
 	ctx context.Context,
 	bot *goram.Bot,
 	updates []goram.Update,
 	data Data,
+) ([]bool, error) {
+	handled := make([]bool, len(updates))
+	errs := []error{}
+	collectMediaGroups := r.hasMediaGroupHandlers()
+	handledMediaGroups := map[string]bool{}
+
+	for i := range updates {
+		u := &updates[i]
+		var err error
+
+		switch {
+		case r.intercept(ctx, bot, u):
+			handled[i] = true
+		case !collectMediaGroups || !isMediaGroupUpdate(u):
+			handled[i], err = r.feedUpdate(ctx, bot, u, cloneData(data))
+		case r.Options.MediaGroupWait > 0:
+			r.collectMediaGroupMessage(ctx, bot, u.Message, cloneData(data))
+			handled[i] = true
+		case handledMediaGroups[mediaGroupKey(u.Message)]:
+			continue
+		default:
+			key := mediaGroupKey(u.Message)
+			handledMediaGroups[key] = true
+			messages := []*goram.Message{}
+			indexes := []int{}
+
+			for j := i; j < len(updates); j++ {
+				if isMediaGroupUpdate(&updates[j]) && mediaGroupKey(updates[j].Message) == key {
+					messages = append(messages, updates[j].Message)
+					indexes = append(indexes, j)
+				}
+			}
+
+			found, groupErr := r.handleMediaGroup(ctx, bot, messages, cloneData(data))
+			err = groupErr
+
+			for _, j := range indexes {
+				handled[j] = found
+			}
+		}
+
+		if err == nil {
+			continue
+		}
+
+		errs = append(errs, fmt.Errorf("update %d: %w", u.UpdateID, err))
+
+		if r.Options.BatchErrorPolicy == BatchAbort {
+			break
+		}
+	}
+
+	return handled, errors.Join(errs...)
+}
+
+// See Router.FeedUpdates()
+//
+// Media group messages are collected in background if RouterOptions.MediaGroupWait is set
+// (the returned bool is true in this case, though the album is not handled yet).
+// Otherwise they are handled as single message albums.
+func (r *Router) FeedUpdate(
+	ctx context.Context,
+	bot *goram.Bot,
+	update *goram.Update,
+	data Data,
 ) (bool, error) {
-	// TODO: support for media group handlers
+	if r.intercept(ctx, bot, update) {
+		return true, nil
+	}
 
-	for _, u := range updates {
-		found, err := r.FeedUpdate(ctx, bot, &u, data)
+	if data == nil {
+		data = Data{}
+	}
+
+	if !isMediaGroupUpdate(update) || !r.hasMediaGroupHandlers() {
+		return r.feedUpdate(ctx, bot, update, data)
+	}
 
-		if err != nil || found {
-			return found, err
+	if r.Options.MediaGroupWait > 0 {
+		r.collectMediaGroupMessage(ctx, bot, update.Message, data)
+		return true, nil
+	}
+
+	return r.handleMediaGroup(ctx, bot, []*goram.Message{update.Message}, data)
+}
+
+// Passes err to router error handlers. The update is considered handled if an error handler returned nil.
+func (r *Router) handleError(
+	ctx context.Context,
+	bot *goram.Bot,
+	update *goram.Update,
+	found bool,
+	err error,
+) (bool, error) {
+	for _, onError := range r.onError {
+		if err == nil {
+			break
+		}
+
+		if err = onError(ctx, bot, update, err); err == nil {
+			return true, nil
 		}
 	}
 
-	return false, nil
+	return found, err
 }
 
 func callHandlers[T any](
@@ -90,6 +296,7 @@ func callHandlers[T any](
 	handlers []handler[T],
 	update T,
 	data Data,
+	inner []Middleware[T],
 ) (bool, error) {
 handlersLoop:
 	for _, handler := range handlers {
@@ -105,9 +312,54 @@ handlersLoop:
 			}
 		}
 
-		err := handler.cb(ctx, bot, update, data)
+		err := chainMiddlewares(handler.cb, inner)(ctx, bot, update, data)
 		return true, err
 	}
 
 	return false, nil
 }
+
+// Wraps cb with middlewares. The first middleware is the outermost one.
+func chainMiddlewares[U any](cb Func[U], middlewares []Middleware[U]) Func[U] {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		cb = middlewares[i](cb)
+	}
+
+	return cb
+}
+
+// Returns a new slice of inherited middlewares followed by router-wide and typed middlewares.
+func joinMiddlewares[U any](inherited []Middleware[U], routerWide []Middleware[any], typed []Middleware[U]) []Middleware[U] {
+	if len(routerWide) == 0 && len(typed) == 0 {
+		return inherited
+	}
+
+	joined := make([]Middleware[U], 0, len(inherited)+len(routerWide)+len(typed))
+	joined = append(joined, inherited...)
+
+	for _, m := range routerWide {
+		joined = append(joined, typedMiddleware[U](m))
+	}
+
+	return append(joined, typed...)
+}
+
+func typedMiddleware[U any](m Middleware[any]) Middleware[U] {
+	return func(next Func[U]) Func[U] {
+		wrapped := m(func(ctx context.Context, bot *goram.Bot, update any, data Data) error {
+			return next(ctx, bot, update.(U), data)
+		})
+
+		return func(ctx context.Context, bot *goram.Bot, update U, data Data) error {
+			return wrapped(ctx, bot, update, data)
+		}
+	}
+}
+
+func cloneData(data Data) Data {
+	if data == nil {
+		return Data{}
+	}
+
+	return maps.Clone(data)
+}
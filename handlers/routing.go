@@ -0,0 +1,417 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"runtime/debug"
+	"slices"
+
+	"github.com/TrixiS/goram"
+)
+
+// Router reached by an update.
+type routeNode[T any] struct {
+	router *Router
+	parent *routeNode[T]
+	depth  int
+	ctx    context.Context // passed to the router filters and handlers, set by the router outer middlewares
+	data   Data
+
+	commands chan routeCommand // nil if the router has no outer middlewares
+	parked   bool              // filters passed and no handler matched, used by the routing only
+}
+
+// Result of a router subtree.
+type routeResult struct {
+	found    bool
+	err      error
+	panicked bool // the panic was not recovered, value is the panic value
+	value    any
+}
+
+// Sent to the routing when a router parks (its filters passed and no handler matched) or is done.
+type routeEvent struct {
+	parked bool
+	result routeResult
+}
+
+// Sent by the routing to a parked router: call fallback handlers or finish with the result of the subtree.
+type routeCommand struct {
+	fallback bool
+	result   routeResult
+}
+
+// Routes an update through a router tree breadth-first.
+//
+// Outer middlewares of a router wrap only its subtree. A router with outer middlewares runs them
+// in its own goroutine, and its next parks until the routers after it are routed,
+// then returns the result of the router subtree. Only one router goroutine runs at a time.
+// Errors and panics are passed to error handlers of the router and then of its parents.
+type routing[T any] struct {
+	bot      *goram.Bot
+	update   T
+	raw      *goram.Update
+	get      func(*Router) *routerHandlers[T]
+	handle   func(n *routeNode[T]) (bool, error) // calls handlers of the router
+	fallback func(n *routeNode[T]) (bool, error) // calls fallback handlers of the router
+
+	events  chan routeEvent
+	queue   []*routeNode[T]
+	parked  []*routeNode[T]               // in the order they were reached
+	results map[*routeNode[T]]routeResult // joined results of the parked routers children
+	final   routeResult                   // result of the root router
+}
+
+// Routes the update through the router and its children.
+//
+// raw is the update that contains update.
+func feedRouter[T any](
+	ctx context.Context,
+	bot *goram.Bot,
+	router *Router,
+	get func(*Router) *routerHandlers[T],
+	update T,
+	raw *goram.Update,
+	data Data,
+) (bool, error) {
+	rt := &routing[T]{
+		bot:    bot,
+		update: update,
+		raw:    raw,
+		get:    get,
+	}
+
+	rt.handle = func(n *routeNode[T]) (bool, error) {
+		return callHandlers(n.ctx, bot, get(n.router).handlers, update, n.data, innerMiddlewares(n, typedInner(get)))
+	}
+
+	rt.fallback = func(n *routeNode[T]) (bool, error) {
+		return callHandlers(n.ctx, bot, get(n.router).fallback, update, n.data, innerMiddlewares(n, typedInner(get)))
+	}
+
+	return rt.run(ctx, router, data)
+}
+
+func (rt *routing[T]) run(ctx context.Context, router *Router, data Data) (bool, error) {
+	rt.events = make(chan routeEvent)
+	rt.results = make(map[*routeNode[T]]routeResult)
+	rt.queue = []*routeNode[T]{{router: router, ctx: ctx, data: data}}
+	stopped := false
+
+	for len(rt.queue) > 0 && !stopped {
+		n := rt.queue[0]
+		rt.queue = rt.queue[1:]
+		stopped = rt.step(n, rt.start(n))
+	}
+
+	if !stopped {
+		rt.callFallbacks()
+	}
+
+	return rt.finish()
+}
+
+// Applies the router event. Returns true if the routing has to stop.
+func (rt *routing[T]) step(n *routeNode[T], event routeEvent) bool {
+	if event.parked {
+		// a router parks again if its fallback handlers did not match
+		if !n.parked {
+			n.parked = true
+			rt.parked = append(rt.parked, n)
+
+			for _, child := range n.router.children {
+				rt.queue = append(rt.queue, &routeNode[T]{
+					router: child,
+					parent: n,
+					depth:  n.depth + 1,
+					ctx:    n.ctx,
+					data:   n.data,
+				})
+			}
+		}
+
+		return false
+	}
+
+	n.parked = false
+	result := event.result
+
+	if n.parent == nil {
+		rt.final = result
+	} else {
+		rt.results[n.parent] = joinResults(rt.results[n.parent], result)
+	}
+
+	return result.found || result.err != nil || result.panicked
+}
+
+// Calls fallback handlers of the parked routers, the deepest routers first.
+func (rt *routing[T]) callFallbacks() {
+	parked := slices.Clone(rt.parked)
+	slices.SortStableFunc(parked, func(a, b *routeNode[T]) int { return b.depth - a.depth })
+
+	for _, n := range parked {
+		// fallback handlers of the router subtree did not match, so it is finished before the router ones run
+		if rt.finishSubtree(n) || rt.step(n, rt.command(n, routeCommand{fallback: true})) {
+			return
+		}
+	}
+}
+
+// Finishes the parked routers, children before parents, so next of every router
+// returns the result of its subtree. Returns the result of the root router.
+func (rt *routing[T]) finish() (bool, error) {
+	rt.finishSubtree(nil)
+
+	if rt.final.panicked {
+		panic(rt.final.value)
+	}
+
+	return rt.final.found, rt.final.err
+}
+
+// Finishes the parked routers in the subtree of root (not including root), children before parents.
+// If root is nil, finishes all of them. Returns true if a router finished with a match, an error or a panic.
+func (rt *routing[T]) finishSubtree(root *routeNode[T]) bool {
+	stopped := false
+
+	for i := len(rt.parked) - 1; i >= 0; i-- {
+		if n := rt.parked[i]; n.parked && n != root && isInSubtree(n, root) {
+			stopped = rt.step(n, rt.command(n, routeCommand{result: rt.results[n]})) || stopped
+		}
+	}
+
+	return stopped
+}
+
+// Starts routing of the router and waits until it parks or is done.
+func (rt *routing[T]) start(n *routeNode[T]) routeEvent {
+	outer := joinMiddlewares(nil, n.router.outer, rt.get(n.router).outer)
+
+	// there is nothing to wrap the subtree with, so the router does not need a goroutine
+	if len(outer) == 0 {
+		return rt.enterInline(n)
+	}
+
+	n.commands = make(chan routeCommand)
+	go rt.visit(n, outer)
+	return <-rt.events
+}
+
+// Sends the command to the parked router and waits until it parks again or is done.
+func (rt *routing[T]) command(n *routeNode[T], command routeCommand) routeEvent {
+	if n.commands == nil {
+		return rt.commandInline(n, command)
+	}
+
+	n.commands <- command
+	return <-rt.events
+}
+
+// Passes the update through the router outer middlewares, filters and handlers.
+// Runs in its own goroutine, next parks inside the outer middlewares until the routing sends a command.
+func (rt *routing[T]) visit(n *routeNode[T], outer []Middleware[T]) {
+	found := false
+	event := routeEvent{}
+
+	defer func() {
+		if v := recover(); v != nil {
+			event = rt.panicEvent(n, v)
+		}
+
+		rt.events <- event
+	}()
+
+	next := func(ctx context.Context, bot *goram.Bot, update T, data Data) error {
+		n.ctx, n.data = ctx, data
+		parked, ownFound, err := rt.enter(n)
+
+		for parked {
+			rt.events <- routeEvent{parked: true}
+			command := <-n.commands
+
+			switch {
+			case command.fallback:
+				ownFound, err = rt.fallback(n)
+				parked = !ownFound && err == nil
+			case command.result.panicked:
+				panic(command.result.value)
+			default:
+				ownFound, err = command.result.found, command.result.err
+				parked = false
+			}
+		}
+
+		found = ownFound
+		return err
+	}
+
+	err := chainMiddlewares(next, outer)(n.ctx, rt.bot, rt.update, n.data)
+	event = rt.doneEvent(n, found, err)
+}
+
+// Does the same as .visit() for a router without outer middlewares.
+func (rt *routing[T]) enterInline(n *routeNode[T]) (event routeEvent) {
+	defer func() {
+		if v := recover(); v != nil {
+			event = rt.panicEvent(n, v)
+		}
+	}()
+
+	parked, found, err := rt.enter(n)
+
+	if parked {
+		return routeEvent{parked: true}
+	}
+
+	return rt.doneEvent(n, found, err)
+}
+
+// Does the same as a parked next in .visit() for a router without outer middlewares.
+func (rt *routing[T]) commandInline(n *routeNode[T], command routeCommand) (event routeEvent) {
+	defer func() {
+		if v := recover(); v != nil {
+			event = rt.panicEvent(n, v)
+		}
+	}()
+
+	if !command.fallback {
+		if command.result.panicked {
+			return routeEvent{result: command.result}
+		}
+
+		return rt.doneEvent(n, command.result.found, command.result.err)
+	}
+
+	found, err := rt.fallback(n)
+
+	if !found && err == nil {
+		return routeEvent{parked: true}
+	}
+
+	return rt.doneEvent(n, found, err)
+}
+
+// Calls the router filters and handlers. Returns parked = true if the filters passed and no handler matched.
+func (rt *routing[T]) enter(n *routeNode[T]) (parked bool, found bool, err error) {
+	ok, err := callFilters(n.ctx, rt.bot, rt.get(n.router).filters, rt.update, n.data)
+
+	if err != nil || !ok {
+		return false, false, err
+	}
+
+	found, err = rt.handle(n)
+	return !found && err == nil, found, err
+}
+
+// Passes err to the router error handlers. Parents get it from their next.
+func (rt *routing[T]) doneEvent(n *routeNode[T], found bool, err error) routeEvent {
+	if err != nil {
+		found, err = n.router.handleError(n.ctx, rt.bot, rt.raw, found, err)
+	}
+
+	return routeEvent{result: routeResult{found: found, err: err}}
+}
+
+func (rt *routing[T]) panicEvent(n *routeNode[T], v any) routeEvent {
+	if !recoversPanics(n) {
+		return routeEvent{result: routeResult{panicked: true, value: v}}
+	}
+
+	return rt.doneEvent(n, false, rt.panicError(v))
+}
+
+func (rt *routing[T]) panicError(v any) *PanicError {
+	return &PanicError{Value: v, Stack: debug.Stack(), Update: rt.raw}
+}
+
+// Returns true if n is root or its descendant. Any router is in the subtree of nil.
+func isInSubtree[T any](n *routeNode[T], root *routeNode[T]) bool {
+	if root == nil {
+		return true
+	}
+
+	for ; n != nil; n = n.parent {
+		if n == root {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Combines results of sibling subtrees.
+func joinResults(a routeResult, b routeResult) routeResult {
+	switch {
+	case a.panicked:
+		return a
+	case b.panicked:
+		return b
+	case a.err == nil:
+		return routeResult{found: a.found || b.found, err: b.err}
+	case b.err == nil:
+		return routeResult{found: a.found || b.found, err: a.err}
+	}
+
+	return routeResult{found: a.found || b.found, err: errors.Join(a.err, b.err)}
+}
+
+// Returns true if the router or any of its parents recovers panics.
+func recoversPanics[T any](n *routeNode[T]) bool {
+	for ; n != nil; n = n.parent {
+		if n.router.Options.RecoverPanics {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Returns inner middlewares of the router and its parents, the root router ones first.
+// Handler timeouts are added before inner middlewares of the router that sets them.
+// typed returns inner middlewares for the update type, it can be nil.
+func innerMiddlewares[T any, U any](n *routeNode[T], typed func(*Router) []Middleware[U]) []Middleware[U] {
+	path := []*Router{}
+
+	for ; n != nil; n = n.parent {
+		path = append(path, n.router)
+	}
+
+	var inner []Middleware[U]
+
+	for i := len(path) - 1; i >= 0; i-- {
+		r := path[i]
+
+		if r.Options.HandlerTimeout > 0 {
+			inner = append(inner, timeoutMiddleware[U](r.Options.HandlerTimeout))
+		}
+
+		var typedMiddlewares []Middleware[U]
+
+		if typed != nil {
+			typedMiddlewares = typed(r)
+		}
+
+		inner = joinMiddlewares(inner, r.inner, typedMiddlewares)
+	}
+
+	return inner
+}
+
+func typedInner[T any](get func(*Router) *routerHandlers[T]) func(*Router) []Middleware[T] {
+	return func(r *Router) []Middleware[T] {
+		return get(r).inner
+	}
+}
+
+func callFilters[T any](ctx context.Context, bot *goram.Bot, filters []Filter[T], update T, data Data) (bool, error) {
+	for _, filter := range filters {
+		ok, err := filter(ctx, bot, update, data)
+
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,249 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/TrixiS/goram"
+)
+
+type routingLog []string
+
+func (l *routingLog) handler(name string) Func[*goram.Message] {
+	return func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+		*l = append(*l, name)
+		return nil
+	}
+}
+
+func (l *routingLog) middleware(name string) Middleware[*goram.Message] {
+	return func(next Func[*goram.Message]) Func[*goram.Message] {
+		return func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+			*l = append(*l, name+"-before")
+			err := next(ctx, bot, message, data)
+			*l = append(*l, name+"-after")
+			return err
+		}
+	}
+}
+
+func rejectMessage(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) (bool, error) {
+	return false, nil
+}
+
+func feedTestMessage(t *testing.T, router *Router) (bool, error) {
+	t.Helper()
+	update := testMessageUpdate(1, 1, 1)
+	return router.FeedUpdate(context.Background(), nil, &update, nil)
+}
+
+func checkRoutingLog(t *testing.T, got routingLog, want ...string) {
+	t.Helper()
+
+	if !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
+
+func TestRoutingOuterMiddlewareSiblings(t *testing.T) {
+	log := routingLog{}
+	router := NewRouter(RouterOptions{})
+	a := router.Group(RouterOptions{}).FilterMessage(rejectMessage).OuterMiddlewareMessage(log.middleware("a"))
+	a.Message(log.handler("a-handler"))
+	router.Group(RouterOptions{}).Message(log.handler("b-handler"))
+
+	found, err := feedTestMessage(t, router)
+
+	if !found || err != nil {
+		t.Fatalf("FeedUpdate() = %v, %v", found, err)
+	}
+
+	checkRoutingLog(t, log, "a-before", "a-after", "b-handler")
+}
+
+func TestRoutingBreadthFirst(t *testing.T) {
+	log := routingLog{}
+	router := NewRouter(RouterOptions{})
+	a := router.Group(RouterOptions{}).OuterMiddlewareMessage(log.middleware("a"))
+	a.Group(RouterOptions{}).Message(log.handler("aa-handler"))
+	router.Group(RouterOptions{}).OuterMiddlewareMessage(log.middleware("b")).Message(log.handler("b-handler"))
+
+	if _, err := feedTestMessage(t, router); err != nil {
+		t.Fatal(err)
+	}
+
+	checkRoutingLog(t, log, "a-before", "b-before", "b-handler", "b-after", "a-after")
+}
+
+func TestRoutingOuterMiddlewareCtx(t *testing.T) {
+	type ctxKey struct{}
+
+	got := map[string]any{}
+	router := NewRouter(RouterOptions{})
+
+	record := func(name string) Filter[*goram.Message] {
+		return func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) (bool, error) {
+			got[name] = ctx.Value(ctxKey{})
+			return false, nil
+		}
+	}
+
+	a := router.Group(RouterOptions{}).OuterMiddlewareMessage(func(next Func[*goram.Message]) Func[*goram.Message] {
+		return func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+			return next(context.WithValue(ctx, ctxKey{}, "a"), bot, message, data)
+		}
+	})
+
+	a.Group(RouterOptions{}).FilterMessage(record("aa"))
+	router.Group(RouterOptions{}).FilterMessage(record("b"))
+
+	if _, err := feedTestMessage(t, router); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(got) != 2 || got["aa"] != "a" || got["b"] != nil {
+		t.Fatalf("filters got ctx values %v, want aa: a and b: nil", got)
+	}
+}
+
+func TestRoutingOuterMiddlewareError(t *testing.T) {
+	boom := errors.New("boom")
+	var rootErr, aErr error
+
+	router := NewRouter(RouterOptions{})
+
+	router.OuterMiddlewareMessage(func(next Func[*goram.Message]) Func[*goram.Message] {
+		return func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+			rootErr = next(ctx, bot, message, data)
+			return rootErr
+		}
+	})
+
+	a := router.Group(RouterOptions{}).OuterMiddlewareMessage(func(next Func[*goram.Message]) Func[*goram.Message] {
+		return func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+			aErr = next(ctx, bot, message, data)
+			return aErr
+		}
+	})
+
+	a.Group(RouterOptions{}).Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+		return boom
+	})
+
+	if _, err := feedTestMessage(t, router); err != boom {
+		t.Fatalf("FeedUpdate() error = %v, want %v", err, boom)
+	}
+
+	if aErr != boom || rootErr != boom {
+		t.Fatalf("next returned %v to a and %v to the root, want %v", aErr, rootErr, boom)
+	}
+}
+
+func TestRoutingOuterMiddlewareHandledError(t *testing.T) {
+	boom := errors.New("boom")
+	var handled error
+	nextErr := boom
+
+	router := NewRouter(RouterOptions{})
+
+	router.OuterMiddlewareMessage(func(next Func[*goram.Message]) Func[*goram.Message] {
+		return func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+			nextErr = next(ctx, bot, message, data)
+			return nextErr
+		}
+	})
+
+	router.Group(RouterOptions{}).
+		OnError(func(ctx context.Context, bot *goram.Bot, update *goram.Update, err error) error {
+			handled = err
+			return nil
+		}).
+		Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+			return boom
+		})
+
+	found, err := feedTestMessage(t, router)
+
+	if !found || err != nil {
+		t.Fatalf("FeedUpdate() = %v, %v, want true, nil", found, err)
+	}
+
+	if handled != boom || nextErr != nil {
+		t.Fatalf("error handler got %v, next returned %v", handled, nextErr)
+	}
+}
+
+func TestRoutingFallbacks(t *testing.T) {
+	log := routingLog{}
+	router := NewRouter(RouterOptions{})
+	router.OuterMiddlewareMessage(log.middleware("root"))
+	router.FallbackMessage(log.handler("root-fallback"))
+	a := router.Group(RouterOptions{}).OuterMiddlewareMessage(log.middleware("a"))
+	a.FallbackMessage(log.handler("a-fallback"), rejectMessage)
+	a.Group(RouterOptions{}).FilterMessage(rejectMessage).FallbackMessage(log.handler("aa-fallback"))
+
+	found, err := feedTestMessage(t, router)
+
+	if !found || err != nil {
+		t.Fatalf("FeedUpdate() = %v, %v", found, err)
+	}
+
+	checkRoutingLog(t, log, "root-before", "a-before", "a-after", "root-fallback", "root-after")
+}
+
+func TestRoutingRecoverPanics(t *testing.T) {
+	var handled, nextErr error
+
+	router := NewRouter(RouterOptions{RecoverPanics: true})
+
+	router.OnError(func(ctx context.Context, bot *goram.Bot, update *goram.Update, err error) error {
+		handled = err
+		return nil
+	})
+
+	a := router.Group(RouterOptions{}).OuterMiddlewareMessage(func(next Func[*goram.Message]) Func[*goram.Message] {
+		return func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+			nextErr = next(ctx, bot, message, data)
+			return nextErr
+		}
+	})
+
+	a.Group(RouterOptions{}).Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+		panic("boom")
+	})
+
+	found, err := feedTestMessage(t, router)
+
+	if !found || err != nil {
+		t.Fatalf("FeedUpdate() = %v, %v, want true, nil", found, err)
+	}
+
+	panicErr := &PanicError{}
+
+	if !errors.As(handled, &panicErr) || panicErr.Value != "boom" || handled != nextErr {
+		t.Fatalf("error handler got %v, next returned %v", handled, nextErr)
+	}
+}
+
+func TestRoutingPanic(t *testing.T) {
+	log := routingLog{}
+	router := NewRouter(RouterOptions{})
+	a := router.Group(RouterOptions{}).OuterMiddlewareMessage(log.middleware("a"))
+
+	a.Group(RouterOptions{}).Message(func(ctx context.Context, bot *goram.Bot, message *goram.Message, data Data) error {
+		panic("boom")
+	})
+
+	defer func() {
+		if v := recover(); v != "boom" {
+			t.Fatalf("recovered %v, want boom", v)
+		}
+
+		checkRoutingLog(t, log, "a-before")
+	}()
+
+	feedTestMessage(t, router)
+	t.Fatal("FeedUpdate() did not panic")
+}
@@ -121,6 +121,7 @@ func main() {
 	generateHandlers(updateType)
 
 	generateTypes(parser, spec.Types)
+	generateContentTypes(parser, spec.Types)
 	generateRequests(parser, spec.Methods)
 	generateMethods(parser, spec.Methods)
 }
@@ -199,6 +200,85 @@ func generateEnums(updateType Type, enums []Enum) {
 	}
 }
 
+// Message fields after "text" that describe the content instead of being the content
+var nonContentMessageFields = []string{
+	"entities",
+	"link_preview_options",
+	"suggested_post_info",
+	"effect_id",
+	"caption",
+	"caption_entities",
+	"show_caption_above_media",
+	"has_media_spoiler",
+	"reply_markup",
+}
+
+func generateContentTypes(parser *Parser, types []Type) {
+	type contentTypeData struct {
+		Name   string
+		GoName string
+		Check  string
+	}
+
+	var messageType *Type
+
+	for i := range types {
+		if types[i].Name == "Message" {
+			messageType = &types[i]
+		}
+	}
+
+	contentTypes := []contentTypeData{}
+	contentStarted := false
+
+	for i := range messageType.Fields {
+		field := &messageType.Fields[i]
+		contentStarted = contentStarted || field.Name == "text"
+
+		if !contentStarted || slices.Contains(nonContentMessageFields, field.Name) {
+			continue
+		}
+
+		parsedField := parser.ParseTypeField(field)
+		spec := parsedField.ParsedSpecType
+		check := "m." + parsedField.GoName
+
+		switch {
+		case spec.ParsedType == ParsedTypeArray:
+			check = "len(" + check + ") > 0"
+		case spec.ParsedType == ParsedTypePrimitive && spec.GoType == "bool":
+		case spec.ParsedType == ParsedTypePrimitive && spec.GoType == "string":
+			check += ` != ""`
+		case spec.ParsedType == ParsedTypePrimitive:
+			check += " != 0"
+		default:
+			check += " != nil"
+		}
+
+		contentTypes = append(contentTypes, contentTypeData{
+			Name:   field.Name,
+			GoName: parsedField.GoName,
+			Check:  check,
+		})
+	}
+
+	buf := bytes.Buffer{}
+
+	if err := tmpls.ExecuteTemplate(&buf, "contentTypes.tmpl", contentTypes); err != nil {
+		panic(err)
+	}
+
+	formattedCode, err := format.Source(buf.Bytes())
+
+	if err != nil {
+		panic("gofmt error: " + err.Error())
+	}
+
+	if err := os.WriteFile("./contenttypes.go", formattedCode, genFilePerm); err != nil {
+		panic(err)
+	}
+}
+
 func generateMethods(parser *Parser, methods []Method) {
 	type methodTemplateData struct {
 		Name        string
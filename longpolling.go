@@ -2,72 +2,315 @@ package goram
 
 import (
 	"context"
-	"net/http"
+	"errors"
+	"sync"
 	"time"
 )
 
-// See goram.LongPollUpdates()
-type LongPollUpdatesOptions struct {
-	RequestOptions GetUpdatesRequest // Initial getUpdates request options
-	Cap            uint              // Optional. Updates channel capacity
-	RetryInterval  time.Duration     // Optional. Sleep for this duration if an error happens on getUpdates request. Default is 0
-	MaxErrors      uint              // Optional. Exit from polling loop after MaxErrors errors in a row. The returned channel gets closed too. Default is unlimited
+const (
+	DefaultConflictBackoff    = time.Second
+	DefaultMaxConflictBackoff = time.Second * 30
+)
+
+var ErrPollerRunning = errors.New("poller is already running")
+
+type PollerOptions struct {
+	RequestOptions     GetUpdatesRequest                                       // Initial getUpdates request options. RequestOptions.Offset is updated after every batch
+	OnUpdates          func(ctx context.Context, updates []Update) error       // Required. Gets called for every non-empty batch. Next batch is requested after OnUpdates returns
+	OnError            func(ctx context.Context, err error)                    // Optional. Gets called on getUpdates and OnUpdates errors
+	RetryInterval      time.Duration                                           // Optional. Sleep for this duration if getUpdates or OnUpdates returns an error. Default is 0
+	ConflictBackoff    time.Duration                                           // Optional. Initial sleep duration on 409 conflict, doubled on every conflict in a row. Default is goram.DefaultConflictBackoff
+	MaxConflictBackoff time.Duration                                           // Optional. Default is goram.DefaultMaxConflictBackoff
+	MaxErrors          uint                                                    // Optional. Exit from polling loop after MaxErrors getUpdates and OnUpdates errors in a row. 409 conflicts are not counted. Default is unlimited
+	DeleteWebhook      bool                                                    // Optional. Delete the webhook on start if it is set, because getUpdates does not work while a webhook is set
+	OffsetStore        OffsetStore                                             // Optional. Offset is loaded on start and saved after OnUpdates returns nil, so handled updates are not redelivered after restart
+	IsProcessed        func(ctx context.Context, updateID int64) (bool, error) // Optional. Idempotency hook. Updates for which it returns true are removed from a batch before OnUpdates
 }
 
-// Polls updates via calling Bot.GetUpdates() in a loop.
-// Streams []Update instead of just Update because it enables better media group handling.
+// Polls updates via calling Bot.GetUpdates() in a loop. See Poller.Run().
+type Poller struct {
+	Options PollerOptions
+
+	bot    *Bot
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func NewPoller(bot *Bot, options PollerOptions) *Poller {
+	if options.ConflictBackoff <= 0 {
+		options.ConflictBackoff = DefaultConflictBackoff
+	}
+
+	if options.MaxConflictBackoff <= 0 {
+		options.MaxConflictBackoff = DefaultMaxConflictBackoff
+	}
+
+	return &Poller{Options: options, bot: bot}
+}
+
+// Polls updates until ctx is done, Poller.Stop() is called or MaxErrors errors happen in a row.
 //
-// This function will panic on conflict (webhook set or another instance calling getUpdates).
-func LongPollUpdates(
-	ctx context.Context,
-	bot *Bot,
-	options *LongPollUpdatesOptions,
-) chan []Update {
-	c := make(chan []Update, options.Cap)
+// If OnUpdates returns an error, the offset is not advanced and the same batch is requested again after RetryInterval.
+//
+// On 409 conflict (webhook is set or another instance is calling getUpdates) the poller sleeps
+// with exponential backoff and jitter instead of failing, so overlapping instances during deploys are fine.
+//
+// Returns nil if stopped with Poller.Stop(), ctx error if ctx is done or the last getUpdates error if MaxErrors is reached.
+func (p *Poller) Run(ctx context.Context) error {
+	pollCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
 
-	go func() {
-		errCount := uint(0)
+	p.mu.Lock()
 
-	loop:
-		for {
-			updates, err := bot.GetUpdates(ctx, &options.RequestOptions)
+	if p.done != nil {
+		p.mu.Unlock()
+		return ErrPollerRunning
+	}
 
-			if err == nil {
-				errCount = 0
+	done := make(chan struct{})
+	p.cancel, p.done = cancel, done
+	p.mu.Unlock()
 
-				if len(updates) == 0 {
-					continue
-				}
+	defer func() {
+		p.mu.Lock()
+		p.cancel, p.done = nil, nil
+		p.mu.Unlock()
+		close(done)
+	}()
+
+	if p.Options.DeleteWebhook {
+		if err := p.deleteWebhook(pollCtx); err != nil {
+			return err
+		}
+	}
+
+	if err := p.loadOffset(pollCtx); err != nil {
+		return err
+	}
+
+	err := p.poll(ctx, pollCtx)
+
+	if pollCtx.Err() != nil && ctx.Err() == nil {
+		p.confirmOffset(ctx)
+		return nil
+	}
+
+	return err
+}
+
+// Stops polling and waits for the last batch to be handled by OnUpdates.
+// Handled updates are confirmed to Telegram before Poller.Run() returns.
+func (p *Poller) Stop() {
+	p.mu.Lock()
+	cancel, done := p.cancel, p.done
+	p.mu.Unlock()
+
+	if cancel == nil {
+		return
+	}
+
+	cancel()
+	<-done
+}
+
+func (p *Poller) poll(ctx context.Context, pollCtx context.Context) error {
+	errCount := uint(0)
+	conflicts := 0
+
+	for {
+		updates, err := p.bot.GetUpdates(pollCtx, &p.Options.RequestOptions)
 
-				options.RequestOptions.Offset = updates[len(updates)-1].UpdateID + 1
-				c <- updates
+		if pollCtx.Err() != nil {
+			return pollCtx.Err()
+		}
+
+		if err == nil {
+			conflicts = 0
+
+			if len(updates) == 0 {
+				errCount = 0
 				continue
 			}
 
-			if err == context.Canceled {
-				break
+			offset := updates[len(updates)-1].UpdateID + 1
+
+			// the offset is not committed on error, so the batch is requested again
+			if err = p.handleUpdates(ctx, updates); err == nil {
+				errCount = 0
+				p.commitOffset(ctx, offset)
+				continue
 			}
 
-			if apiError, ok := err.(*APIError); ok && apiError.ErrorCode == http.StatusConflict {
-				panic(apiError)
+			if pollCtx.Err() != nil {
+				p.onError(ctx, err)
+				return pollCtx.Err()
 			}
+		}
+
+		p.onError(ctx, err)
+		delay := p.Options.RetryInterval
 
-			if options.MaxErrors > 0 {
-				errCount++
+		// conflicts are expected while another instance is shutting down, so they are not counted
+		if errors.Is(err, ErrConflict) {
+			delay = backoff(p.Options.ConflictBackoff, p.Options.MaxConflictBackoff, conflicts)
+			conflicts++
+		} else if p.Options.MaxErrors > 0 {
+			errCount++
 
-				if errCount >= options.MaxErrors {
-					break
-				}
+			if errCount >= p.Options.MaxErrors {
+				return err
 			}
+		}
+
+		select {
+		case <-pollCtx.Done():
+			return pollCtx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+func (p *Poller) loadOffset(ctx context.Context) error {
+	if p.Options.OffsetStore == nil {
+		return nil
+	}
+
+	offset, err := p.Options.OffsetStore.LoadOffset(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if offset > p.Options.RequestOptions.Offset {
+		p.Options.RequestOptions.Offset = offset
+	}
+
+	return nil
+}
+
+func (p *Poller) handleUpdates(ctx context.Context, updates []Update) error {
+	if updates = p.skipProcessed(ctx, updates); len(updates) == 0 {
+		return nil
+	}
+
+	return p.Options.OnUpdates(ctx, updates)
+}
+
+// Removes updates for which IsProcessed hook returns true.
+func (p *Poller) skipProcessed(ctx context.Context, updates []Update) []Update {
+	if p.Options.IsProcessed == nil {
+		return updates
+	}
+
+	filtered := updates[:0]
+
+	for _, u := range updates {
+		processed, err := p.Options.IsProcessed(ctx, u.UpdateID)
+
+		if err != nil {
+			p.onError(ctx, err)
+		} else if processed {
+			continue
+		}
+
+		filtered = append(filtered, u)
+	}
+
+	return filtered
+}
+
+func (p *Poller) commitOffset(ctx context.Context, offset int64) {
+	p.Options.RequestOptions.Offset = offset
+
+	if p.Options.OffsetStore == nil {
+		return
+	}
+
+	if err := p.Options.OffsetStore.SaveOffset(ctx, offset); err != nil {
+		p.onError(ctx, err)
+	}
+}
+
+func (p *Poller) deleteWebhook(ctx context.Context) error {
+	info, err := p.bot.GetWebhookInfo(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	if info.URL == "" {
+		return nil
+	}
+
+	return p.bot.DeleteWebhookVoid(ctx, &DeleteWebhookRequest{})
+}
+
+// Telegram confirms updates only when getUpdates is called with a greater offset.
+// Without this, the last handled batch would be delivered again on the next start.
+func (p *Poller) confirmOffset(ctx context.Context) {
+	if p.Options.RequestOptions.Offset == 0 {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	_, err := p.bot.GetUpdates(ctx, &GetUpdatesRequest{
+		Offset:         p.Options.RequestOptions.Offset,
+		Limit:          1,
+		AllowedUpdates: p.Options.RequestOptions.AllowedUpdates,
+	})
+
+	if err != nil {
+		p.onError(ctx, err)
+	}
+}
+
+func (p *Poller) onError(ctx context.Context, err error) {
+	if p.Options.OnError != nil {
+		p.Options.OnError(ctx, err)
+	}
+}
+
+// See goram.LongPollUpdates()
+type LongPollUpdatesOptions struct {
+	RequestOptions GetUpdatesRequest // Initial getUpdates request options
+	Cap            uint              // Optional. Updates channel capacity
+	RetryInterval  time.Duration     // Optional. Sleep for this duration if an error happens on getUpdates request. Default is 0
+	MaxErrors      uint              // Optional. Exit from polling loop after MaxErrors errors in a row, 409 conflicts are not counted. The returned channel gets closed too. Default is unlimited
+}
+
+// Polls updates via calling Bot.GetUpdates() in a loop.
+// Streams []Update instead of just Update because it enables better media group handling.
+//
+// Errors are not reported, conflicts (webhook set or another instance calling getUpdates) are retried with backoff.
+// Use goram.Poller if you need errors or graceful stop.
+func LongPollUpdates(
+	ctx context.Context,
+	bot *Bot,
+	options *LongPollUpdatesOptions,
+) chan []Update {
+	c := make(chan []Update, options.Cap)
+
+	poller := NewPoller(bot, PollerOptions{
+		RequestOptions: options.RequestOptions,
+		RetryInterval:  options.RetryInterval,
+		MaxErrors:      options.MaxErrors,
+		OnUpdates: func(ctx context.Context, updates []Update) error {
+			options.RequestOptions.Offset = updates[len(updates)-1].UpdateID + 1
 
 			select {
+			case c <- updates:
+				return nil
 			case <-ctx.Done():
-				break loop
-			case <-time.After(options.RetryInterval):
-				continue
+				return ctx.Err()
 			}
-		}
+		},
+	})
 
+	go func() {
+		poller.Run(ctx)
 		close(c)
 	}()
 
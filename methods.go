@@ -10,7 +10,7 @@ import (
 //
 // https://core.telegram.org/bots/api#getupdates
 func (b *Bot) GetUpdates(ctx context.Context, request *GetUpdatesRequest) (r []Update, err error) {
-	res, err := makeRequest[[]Update](ctx, b.Options.Client, b.baseURL, "getUpdates", b.Options.FloodHandler, request)
+	res, err := makeRequest[[]Update](ctx, b, "getUpdates", request)
 
 	if err != nil {
 		return r, err
@@ -25,7 +25,7 @@ func (b *Bot) GetUpdates(ctx context.Context, request *GetUpdatesRequest) (r []U
 //
 // https://core.telegram.org/bots/api#setwebhook
 func (b *Bot) SetWebhook(ctx context.Context, request *SetWebhookRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setWebhook", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setWebhook", request)
 
 	if err != nil {
 		return r, err
@@ -37,14 +37,14 @@ func (b *Bot) SetWebhook(ctx context.Context, request *SetWebhookRequest) (r boo
 // Does the same as Bot.SetWebhook, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetWebhookVoid(ctx context.Context, request *SetWebhookRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setWebhook", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setWebhook", request)
 }
 
 // Use this method to remove webhook integration if you decide to switch back to getUpdates. Returns True on success.
 //
 // https://core.telegram.org/bots/api#deletewebhook
 func (b *Bot) DeleteWebhook(ctx context.Context, request *DeleteWebhookRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "deleteWebhook", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "deleteWebhook", request)
 
 	if err != nil {
 		return r, err
@@ -56,14 +56,14 @@ func (b *Bot) DeleteWebhook(ctx context.Context, request *DeleteWebhookRequest)
 // Does the same as Bot.DeleteWebhook, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeleteWebhookVoid(ctx context.Context, request *DeleteWebhookRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "deleteWebhook", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "deleteWebhook", request)
 }
 
 // Use this method to get current webhook status. Requires no parameters. On success, returns a WebhookInfo object. If the bot is using getUpdates, will return an object with the url field empty.
 //
 // https://core.telegram.org/bots/api#getwebhookinfo
 func (b *Bot) GetWebhookInfo(ctx context.Context) (r *WebhookInfo, err error) {
-	res, err := makeRequest[*WebhookInfo](ctx, b.Options.Client, b.baseURL, "getWebhookInfo", b.Options.FloodHandler, nil)
+	res, err := makeRequest[*WebhookInfo](ctx, b, "getWebhookInfo", nil)
 
 	if err != nil {
 		return r, err
@@ -76,7 +76,7 @@ func (b *Bot) GetWebhookInfo(ctx context.Context) (r *WebhookInfo, err error) {
 //
 // https://core.telegram.org/bots/api#getme
 func (b *Bot) GetMe(ctx context.Context) (r *User, err error) {
-	res, err := makeRequest[*User](ctx, b.Options.Client, b.baseURL, "getMe", b.Options.FloodHandler, nil)
+	res, err := makeRequest[*User](ctx, b, "getMe", nil)
 
 	if err != nil {
 		return r, err
@@ -89,7 +89,7 @@ func (b *Bot) GetMe(ctx context.Context) (r *User, err error) {
 //
 // https://core.telegram.org/bots/api#logout
 func (b *Bot) LogOut(ctx context.Context) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "logOut", b.Options.FloodHandler, nil)
+	res, err := makeRequest[bool](ctx, b, "logOut", nil)
 
 	if err != nil {
 		return r, err
@@ -102,7 +102,7 @@ func (b *Bot) LogOut(ctx context.Context) (r bool, err error) {
 //
 // https://core.telegram.org/bots/api#close
 func (b *Bot) Close(ctx context.Context) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "close", b.Options.FloodHandler, nil)
+	res, err := makeRequest[bool](ctx, b, "close", nil)
 
 	if err != nil {
 		return r, err
@@ -115,7 +115,7 @@ func (b *Bot) Close(ctx context.Context) (r bool, err error) {
 //
 // https://core.telegram.org/bots/api#sendmessage
 func (b *Bot) SendMessage(ctx context.Context, request *SendMessageRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendMessage", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendMessage", request)
 
 	if err != nil {
 		return r, err
@@ -127,14 +127,14 @@ func (b *Bot) SendMessage(ctx context.Context, request *SendMessageRequest) (r *
 // Does the same as Bot.SendMessage, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendMessageVoid(ctx context.Context, request *SendMessageRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendMessage", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendMessage", request)
 }
 
 // Use this method to forward messages of any kind. Service messages and messages with protected content can't be forwarded. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#forwardmessage
 func (b *Bot) ForwardMessage(ctx context.Context, request *ForwardMessageRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "forwardMessage", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "forwardMessage", request)
 
 	if err != nil {
 		return r, err
@@ -146,14 +146,14 @@ func (b *Bot) ForwardMessage(ctx context.Context, request *ForwardMessageRequest
 // Does the same as Bot.ForwardMessage, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) ForwardMessageVoid(ctx context.Context, request *ForwardMessageRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "forwardMessage", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "forwardMessage", request)
 }
 
 // Use this method to forward multiple messages of any kind. If some of the specified messages can't be found or forwarded, they are skipped. Service messages and messages with protected content can't be forwarded. Album grouping is kept for forwarded messages. On success, an array of MessageId of the sent messages is returned.
 //
 // https://core.telegram.org/bots/api#forwardmessages
 func (b *Bot) ForwardMessages(ctx context.Context, request *ForwardMessagesRequest) (r []MessageId, err error) {
-	res, err := makeRequest[[]MessageId](ctx, b.Options.Client, b.baseURL, "forwardMessages", b.Options.FloodHandler, request)
+	res, err := makeRequest[[]MessageId](ctx, b, "forwardMessages", request)
 
 	if err != nil {
 		return r, err
@@ -165,14 +165,14 @@ func (b *Bot) ForwardMessages(ctx context.Context, request *ForwardMessagesReque
 // Does the same as Bot.ForwardMessages, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) ForwardMessagesVoid(ctx context.Context, request *ForwardMessagesRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "forwardMessages", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "forwardMessages", request)
 }
 
 // Use this method to copy messages of any kind. Service messages, paid media messages, giveaway messages, giveaway winners messages, and invoice messages can't be copied. A quiz poll can be copied only if the value of the field correct_option_id is known to the bot. The method is analogous to the method forwardMessage, but the copied message doesn't have a link to the original message. Returns the MessageId of the sent message on success.
 //
 // https://core.telegram.org/bots/api#copymessage
 func (b *Bot) CopyMessage(ctx context.Context, request *CopyMessageRequest) (r *MessageId, err error) {
-	res, err := makeRequest[*MessageId](ctx, b.Options.Client, b.baseURL, "copyMessage", b.Options.FloodHandler, request)
+	res, err := makeRequest[*MessageId](ctx, b, "copyMessage", request)
 
 	if err != nil {
 		return r, err
@@ -184,14 +184,14 @@ func (b *Bot) CopyMessage(ctx context.Context, request *CopyMessageRequest) (r *
 // Does the same as Bot.CopyMessage, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) CopyMessageVoid(ctx context.Context, request *CopyMessageRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "copyMessage", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "copyMessage", request)
 }
 
 // Use this method to copy messages of any kind. If some of the specified messages can't be found or copied, they are skipped. Service messages, paid media messages, giveaway messages, giveaway winners messages, and invoice messages can't be copied. A quiz poll can be copied only if the value of the field correct_option_id is known to the bot. The method is analogous to the method forwardMessages, but the copied messages don't have a link to the original message. Album grouping is kept for copied messages. On success, an array of MessageId of the sent messages is returned.
 //
 // https://core.telegram.org/bots/api#copymessages
 func (b *Bot) CopyMessages(ctx context.Context, request *CopyMessagesRequest) (r []MessageId, err error) {
-	res, err := makeRequest[[]MessageId](ctx, b.Options.Client, b.baseURL, "copyMessages", b.Options.FloodHandler, request)
+	res, err := makeRequest[[]MessageId](ctx, b, "copyMessages", request)
 
 	if err != nil {
 		return r, err
@@ -203,14 +203,14 @@ func (b *Bot) CopyMessages(ctx context.Context, request *CopyMessagesRequest) (r
 // Does the same as Bot.CopyMessages, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) CopyMessagesVoid(ctx context.Context, request *CopyMessagesRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "copyMessages", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "copyMessages", request)
 }
 
 // Use this method to send photos. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#sendphoto
 func (b *Bot) SendPhoto(ctx context.Context, request *SendPhotoRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendPhoto", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendPhoto", request)
 
 	if err != nil {
 		return r, err
@@ -222,7 +222,7 @@ func (b *Bot) SendPhoto(ctx context.Context, request *SendPhotoRequest) (r *Mess
 // Does the same as Bot.SendPhoto, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendPhotoVoid(ctx context.Context, request *SendPhotoRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendPhoto", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendPhoto", request)
 }
 
 // Use this method to send audio files, if you want Telegram clients to display them in the music player. Your audio must be in the .MP3 or .M4A format. On success, the sent Message is returned. Bots can currently send audio files of up to 50 MB in size, this limit may be changed in the future.
@@ -231,7 +231,7 @@ func (b *Bot) SendPhotoVoid(ctx context.Context, request *SendPhotoRequest) erro
 //
 // https://core.telegram.org/bots/api#sendaudio
 func (b *Bot) SendAudio(ctx context.Context, request *SendAudioRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendAudio", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendAudio", request)
 
 	if err != nil {
 		return r, err
@@ -243,14 +243,14 @@ func (b *Bot) SendAudio(ctx context.Context, request *SendAudioRequest) (r *Mess
 // Does the same as Bot.SendAudio, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendAudioVoid(ctx context.Context, request *SendAudioRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendAudio", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendAudio", request)
 }
 
 // Use this method to send general files. On success, the sent Message is returned. Bots can currently send files of any type of up to 50 MB in size, this limit may be changed in the future.
 //
 // https://core.telegram.org/bots/api#senddocument
 func (b *Bot) SendDocument(ctx context.Context, request *SendDocumentRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendDocument", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendDocument", request)
 
 	if err != nil {
 		return r, err
@@ -262,14 +262,14 @@ func (b *Bot) SendDocument(ctx context.Context, request *SendDocumentRequest) (r
 // Does the same as Bot.SendDocument, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendDocumentVoid(ctx context.Context, request *SendDocumentRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendDocument", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendDocument", request)
 }
 
 // Use this method to send video files, Telegram clients support MPEG4 videos (other formats may be sent as Document). On success, the sent Message is returned. Bots can currently send video files of up to 50 MB in size, this limit may be changed in the future.
 //
 // https://core.telegram.org/bots/api#sendvideo
 func (b *Bot) SendVideo(ctx context.Context, request *SendVideoRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendVideo", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendVideo", request)
 
 	if err != nil {
 		return r, err
@@ -281,14 +281,14 @@ func (b *Bot) SendVideo(ctx context.Context, request *SendVideoRequest) (r *Mess
 // Does the same as Bot.SendVideo, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendVideoVoid(ctx context.Context, request *SendVideoRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendVideo", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendVideo", request)
 }
 
 // Use this method to send animation files (GIF or H.264/MPEG-4 AVC video without sound). On success, the sent Message is returned. Bots can currently send animation files of up to 50 MB in size, this limit may be changed in the future.
 //
 // https://core.telegram.org/bots/api#sendanimation
 func (b *Bot) SendAnimation(ctx context.Context, request *SendAnimationRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendAnimation", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendAnimation", request)
 
 	if err != nil {
 		return r, err
@@ -300,14 +300,14 @@ func (b *Bot) SendAnimation(ctx context.Context, request *SendAnimationRequest)
 // Does the same as Bot.SendAnimation, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendAnimationVoid(ctx context.Context, request *SendAnimationRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendAnimation", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendAnimation", request)
 }
 
 // Use this method to send audio files, if you want Telegram clients to display the file as a playable voice message. For this to work, your audio must be in an .OGG file encoded with OPUS, or in .MP3 format, or in .M4A format (other formats may be sent as Audio or Document). On success, the sent Message is returned. Bots can currently send voice messages of up to 50 MB in size, this limit may be changed in the future.
 //
 // https://core.telegram.org/bots/api#sendvoice
 func (b *Bot) SendVoice(ctx context.Context, request *SendVoiceRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendVoice", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendVoice", request)
 
 	if err != nil {
 		return r, err
@@ -319,14 +319,14 @@ func (b *Bot) SendVoice(ctx context.Context, request *SendVoiceRequest) (r *Mess
 // Does the same as Bot.SendVoice, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendVoiceVoid(ctx context.Context, request *SendVoiceRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendVoice", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendVoice", request)
 }
 
 // As of v.4.0, Telegram clients support rounded square MPEG4 videos of up to 1 minute long. Use this method to send video messages. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#sendvideonote
 func (b *Bot) SendVideoNote(ctx context.Context, request *SendVideoNoteRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendVideoNote", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendVideoNote", request)
 
 	if err != nil {
 		return r, err
@@ -338,14 +338,14 @@ func (b *Bot) SendVideoNote(ctx context.Context, request *SendVideoNoteRequest)
 // Does the same as Bot.SendVideoNote, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendVideoNoteVoid(ctx context.Context, request *SendVideoNoteRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendVideoNote", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendVideoNote", request)
 }
 
 // Use this method to send paid media. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#sendpaidmedia
 func (b *Bot) SendPaidMedia(ctx context.Context, request *SendPaidMediaRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendPaidMedia", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendPaidMedia", request)
 
 	if err != nil {
 		return r, err
@@ -357,14 +357,14 @@ func (b *Bot) SendPaidMedia(ctx context.Context, request *SendPaidMediaRequest)
 // Does the same as Bot.SendPaidMedia, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendPaidMediaVoid(ctx context.Context, request *SendPaidMediaRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendPaidMedia", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendPaidMedia", request)
 }
 
 // Use this method to send a group of photos, videos, documents or audios as an album. Documents and audio files can be only grouped in an album with messages of the same type. On success, an array of Message objects that were sent is returned.
 //
 // https://core.telegram.org/bots/api#sendmediagroup
 func (b *Bot) SendMediaGroup(ctx context.Context, request *SendMediaGroupRequest) (r []Message, err error) {
-	res, err := makeRequest[[]Message](ctx, b.Options.Client, b.baseURL, "sendMediaGroup", b.Options.FloodHandler, request)
+	res, err := makeRequest[[]Message](ctx, b, "sendMediaGroup", request)
 
 	if err != nil {
 		return r, err
@@ -376,14 +376,14 @@ func (b *Bot) SendMediaGroup(ctx context.Context, request *SendMediaGroupRequest
 // Does the same as Bot.SendMediaGroup, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendMediaGroupVoid(ctx context.Context, request *SendMediaGroupRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendMediaGroup", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendMediaGroup", request)
 }
 
 // Use this method to send point on the map. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#sendlocation
 func (b *Bot) SendLocation(ctx context.Context, request *SendLocationRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendLocation", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendLocation", request)
 
 	if err != nil {
 		return r, err
@@ -395,14 +395,14 @@ func (b *Bot) SendLocation(ctx context.Context, request *SendLocationRequest) (r
 // Does the same as Bot.SendLocation, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendLocationVoid(ctx context.Context, request *SendLocationRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendLocation", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendLocation", request)
 }
 
 // Use this method to send information about a venue. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#sendvenue
 func (b *Bot) SendVenue(ctx context.Context, request *SendVenueRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendVenue", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendVenue", request)
 
 	if err != nil {
 		return r, err
@@ -414,14 +414,14 @@ func (b *Bot) SendVenue(ctx context.Context, request *SendVenueRequest) (r *Mess
 // Does the same as Bot.SendVenue, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendVenueVoid(ctx context.Context, request *SendVenueRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendVenue", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendVenue", request)
 }
 
 // Use this method to send phone contacts. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#sendcontact
 func (b *Bot) SendContact(ctx context.Context, request *SendContactRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendContact", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendContact", request)
 
 	if err != nil {
 		return r, err
@@ -433,14 +433,14 @@ func (b *Bot) SendContact(ctx context.Context, request *SendContactRequest) (r *
 // Does the same as Bot.SendContact, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendContactVoid(ctx context.Context, request *SendContactRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendContact", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendContact", request)
 }
 
 // Use this method to send a native poll. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#sendpoll
 func (b *Bot) SendPoll(ctx context.Context, request *SendPollRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendPoll", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendPoll", request)
 
 	if err != nil {
 		return r, err
@@ -452,14 +452,14 @@ func (b *Bot) SendPoll(ctx context.Context, request *SendPollRequest) (r *Messag
 // Does the same as Bot.SendPoll, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendPollVoid(ctx context.Context, request *SendPollRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendPoll", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendPoll", request)
 }
 
 // Use this method to send a checklist on behalf of a connected business account. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#sendchecklist
 func (b *Bot) SendChecklist(ctx context.Context, request *SendChecklistRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendChecklist", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendChecklist", request)
 
 	if err != nil {
 		return r, err
@@ -471,14 +471,14 @@ func (b *Bot) SendChecklist(ctx context.Context, request *SendChecklistRequest)
 // Does the same as Bot.SendChecklist, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendChecklistVoid(ctx context.Context, request *SendChecklistRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendChecklist", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendChecklist", request)
 }
 
 // Use this method to send an animated emoji that will display a random value. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#senddice
 func (b *Bot) SendDice(ctx context.Context, request *SendDiceRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendDice", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendDice", request)
 
 	if err != nil {
 		return r, err
@@ -490,14 +490,14 @@ func (b *Bot) SendDice(ctx context.Context, request *SendDiceRequest) (r *Messag
 // Does the same as Bot.SendDice, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendDiceVoid(ctx context.Context, request *SendDiceRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendDice", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendDice", request)
 }
 
 // Use this method to stream a partial message to a user while the message is being generated. Returns True on success.
 //
 // https://core.telegram.org/bots/api#sendmessagedraft
 func (b *Bot) SendMessageDraft(ctx context.Context, request *SendMessageDraftRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "sendMessageDraft", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "sendMessageDraft", request)
 
 	if err != nil {
 		return r, err
@@ -509,7 +509,7 @@ func (b *Bot) SendMessageDraft(ctx context.Context, request *SendMessageDraftReq
 // Does the same as Bot.SendMessageDraft, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendMessageDraftVoid(ctx context.Context, request *SendMessageDraftRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendMessageDraft", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendMessageDraft", request)
 }
 
 // Use this method when you need to tell the user that something is happening on the bot's side. The status is set for 5 seconds or less (when a message arrives from your bot, Telegram clients clear its typing status). Returns True on success.
@@ -518,7 +518,7 @@ func (b *Bot) SendMessageDraftVoid(ctx context.Context, request *SendMessageDraf
 //
 // https://core.telegram.org/bots/api#sendchataction
 func (b *Bot) SendChatAction(ctx context.Context, request *SendChatActionRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "sendChatAction", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "sendChatAction", request)
 
 	if err != nil {
 		return r, err
@@ -530,14 +530,14 @@ func (b *Bot) SendChatAction(ctx context.Context, request *SendChatActionRequest
 // Does the same as Bot.SendChatAction, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendChatActionVoid(ctx context.Context, request *SendChatActionRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendChatAction", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendChatAction", request)
 }
 
 // Use this method to change the chosen reactions on a message. Service messages of some types can't be reacted to. Automatically forwarded messages from a channel to its discussion group have the same available reactions as messages in the channel. Bots can't use paid reactions. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setmessagereaction
 func (b *Bot) SetMessageReaction(ctx context.Context, request *SetMessageReactionRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setMessageReaction", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setMessageReaction", request)
 
 	if err != nil {
 		return r, err
@@ -549,14 +549,14 @@ func (b *Bot) SetMessageReaction(ctx context.Context, request *SetMessageReactio
 // Does the same as Bot.SetMessageReaction, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetMessageReactionVoid(ctx context.Context, request *SetMessageReactionRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setMessageReaction", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setMessageReaction", request)
 }
 
 // Use this method to get a list of profile pictures for a user. Returns a UserProfilePhotos object.
 //
 // https://core.telegram.org/bots/api#getuserprofilephotos
 func (b *Bot) GetUserProfilePhotos(ctx context.Context, request *GetUserProfilePhotosRequest) (r *UserProfilePhotos, err error) {
-	res, err := makeRequest[*UserProfilePhotos](ctx, b.Options.Client, b.baseURL, "getUserProfilePhotos", b.Options.FloodHandler, request)
+	res, err := makeRequest[*UserProfilePhotos](ctx, b, "getUserProfilePhotos", request)
 
 	if err != nil {
 		return r, err
@@ -569,7 +569,7 @@ func (b *Bot) GetUserProfilePhotos(ctx context.Context, request *GetUserProfileP
 //
 // https://core.telegram.org/bots/api#getuserprofileaudios
 func (b *Bot) GetUserProfileAudios(ctx context.Context, request *GetUserProfileAudiosRequest) (r *UserProfileAudios, err error) {
-	res, err := makeRequest[*UserProfileAudios](ctx, b.Options.Client, b.baseURL, "getUserProfileAudios", b.Options.FloodHandler, request)
+	res, err := makeRequest[*UserProfileAudios](ctx, b, "getUserProfileAudios", request)
 
 	if err != nil {
 		return r, err
@@ -582,7 +582,7 @@ func (b *Bot) GetUserProfileAudios(ctx context.Context, request *GetUserProfileA
 //
 // https://core.telegram.org/bots/api#setuseremojistatus
 func (b *Bot) SetUserEmojiStatus(ctx context.Context, request *SetUserEmojiStatusRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setUserEmojiStatus", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setUserEmojiStatus", request)
 
 	if err != nil {
 		return r, err
@@ -594,7 +594,7 @@ func (b *Bot) SetUserEmojiStatus(ctx context.Context, request *SetUserEmojiStatu
 // Does the same as Bot.SetUserEmojiStatus, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetUserEmojiStatusVoid(ctx context.Context, request *SetUserEmojiStatusRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setUserEmojiStatus", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setUserEmojiStatus", request)
 }
 
 // Use this method to get basic information about a file and prepare it for downloading. For the moment, bots can download files of up to 20MB in size. On success, a File object is returned. The file can then be downloaded via the link https://api.telegram.org/file/bot<token>/<file_path>, where <file_path> is taken from the response. It is guaranteed that the link will be valid for at least 1 hour. When the link expires, a new one can be requested by calling getFile again.
@@ -603,7 +603,7 @@ func (b *Bot) SetUserEmojiStatusVoid(ctx context.Context, request *SetUserEmojiS
 //
 // https://core.telegram.org/bots/api#getfile
 func (b *Bot) GetFile(ctx context.Context, request *GetFileRequest) (r *File, err error) {
-	res, err := makeRequest[*File](ctx, b.Options.Client, b.baseURL, "getFile", b.Options.FloodHandler, request)
+	res, err := makeRequest[*File](ctx, b, "getFile", request)
 
 	if err != nil {
 		return r, err
@@ -616,7 +616,7 @@ func (b *Bot) GetFile(ctx context.Context, request *GetFileRequest) (r *File, er
 //
 // https://core.telegram.org/bots/api#banchatmember
 func (b *Bot) BanChatMember(ctx context.Context, request *BanChatMemberRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "banChatMember", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "banChatMember", request)
 
 	if err != nil {
 		return r, err
@@ -628,14 +628,14 @@ func (b *Bot) BanChatMember(ctx context.Context, request *BanChatMemberRequest)
 // Does the same as Bot.BanChatMember, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) BanChatMemberVoid(ctx context.Context, request *BanChatMemberRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "banChatMember", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "banChatMember", request)
 }
 
 // Use this method to unban a previously banned user in a supergroup or channel. The user will not return to the group or channel automatically, but will be able to join via link, etc. The bot must be an administrator for this to work. By default, this method guarantees that after the call the user is not a member of the chat, but will be able to join it. So if the user is a member of the chat they will also be removed from the chat. If you don't want this, use the parameter only_if_banned. Returns True on success.
 //
 // https://core.telegram.org/bots/api#unbanchatmember
 func (b *Bot) UnbanChatMember(ctx context.Context, request *UnbanChatMemberRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "unbanChatMember", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "unbanChatMember", request)
 
 	if err != nil {
 		return r, err
@@ -647,14 +647,14 @@ func (b *Bot) UnbanChatMember(ctx context.Context, request *UnbanChatMemberReque
 // Does the same as Bot.UnbanChatMember, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) UnbanChatMemberVoid(ctx context.Context, request *UnbanChatMemberRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "unbanChatMember", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "unbanChatMember", request)
 }
 
 // Use this method to restrict a user in a supergroup. The bot must be an administrator in the supergroup for this to work and must have the appropriate administrator rights. Pass True for all permissions to lift restrictions from a user. Returns True on success.
 //
 // https://core.telegram.org/bots/api#restrictchatmember
 func (b *Bot) RestrictChatMember(ctx context.Context, request *RestrictChatMemberRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "restrictChatMember", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "restrictChatMember", request)
 
 	if err != nil {
 		return r, err
@@ -666,14 +666,14 @@ func (b *Bot) RestrictChatMember(ctx context.Context, request *RestrictChatMembe
 // Does the same as Bot.RestrictChatMember, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) RestrictChatMemberVoid(ctx context.Context, request *RestrictChatMemberRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "restrictChatMember", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "restrictChatMember", request)
 }
 
 // Use this method to promote or demote a user in a supergroup or a channel. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Pass False for all boolean parameters to demote a user. Returns True on success.
 //
 // https://core.telegram.org/bots/api#promotechatmember
 func (b *Bot) PromoteChatMember(ctx context.Context, request *PromoteChatMemberRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "promoteChatMember", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "promoteChatMember", request)
 
 	if err != nil {
 		return r, err
@@ -685,14 +685,14 @@ func (b *Bot) PromoteChatMember(ctx context.Context, request *PromoteChatMemberR
 // Does the same as Bot.PromoteChatMember, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) PromoteChatMemberVoid(ctx context.Context, request *PromoteChatMemberRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "promoteChatMember", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "promoteChatMember", request)
 }
 
 // Use this method to set a custom title for an administrator in a supergroup promoted by the bot. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setchatadministratorcustomtitle
 func (b *Bot) SetChatAdministratorCustomTitle(ctx context.Context, request *SetChatAdministratorCustomTitleRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setChatAdministratorCustomTitle", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setChatAdministratorCustomTitle", request)
 
 	if err != nil {
 		return r, err
@@ -704,14 +704,14 @@ func (b *Bot) SetChatAdministratorCustomTitle(ctx context.Context, request *SetC
 // Does the same as Bot.SetChatAdministratorCustomTitle, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetChatAdministratorCustomTitleVoid(ctx context.Context, request *SetChatAdministratorCustomTitleRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setChatAdministratorCustomTitle", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setChatAdministratorCustomTitle", request)
 }
 
 // Use this method to set a tag for a regular member in a group or a supergroup. The bot must be an administrator in the chat for this to work and must have the can_manage_tags administrator right. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setchatmembertag
 func (b *Bot) SetChatMemberTag(ctx context.Context, request *SetChatMemberTagRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setChatMemberTag", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setChatMemberTag", request)
 
 	if err != nil {
 		return r, err
@@ -723,14 +723,14 @@ func (b *Bot) SetChatMemberTag(ctx context.Context, request *SetChatMemberTagReq
 // Does the same as Bot.SetChatMemberTag, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetChatMemberTagVoid(ctx context.Context, request *SetChatMemberTagRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setChatMemberTag", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setChatMemberTag", request)
 }
 
 // Use this method to ban a channel chat in a supergroup or a channel. Until the chat is unbanned, the owner of the banned chat won't be able to send messages on behalf of any of their channels. The bot must be an administrator in the supergroup or channel for this to work and must have the appropriate administrator rights. Returns True on success.
 //
 // https://core.telegram.org/bots/api#banchatsenderchat
 func (b *Bot) BanChatSenderChat(ctx context.Context, request *BanChatSenderChatRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "banChatSenderChat", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "banChatSenderChat", request)
 
 	if err != nil {
 		return r, err
@@ -742,14 +742,14 @@ func (b *Bot) BanChatSenderChat(ctx context.Context, request *BanChatSenderChatR
 // Does the same as Bot.BanChatSenderChat, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) BanChatSenderChatVoid(ctx context.Context, request *BanChatSenderChatRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "banChatSenderChat", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "banChatSenderChat", request)
 }
 
 // Use this method to unban a previously banned channel chat in a supergroup or channel. The bot must be an administrator for this to work and must have the appropriate administrator rights. Returns True on success.
 //
 // https://core.telegram.org/bots/api#unbanchatsenderchat
 func (b *Bot) UnbanChatSenderChat(ctx context.Context, request *UnbanChatSenderChatRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "unbanChatSenderChat", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "unbanChatSenderChat", request)
 
 	if err != nil {
 		return r, err
@@ -761,14 +761,14 @@ func (b *Bot) UnbanChatSenderChat(ctx context.Context, request *UnbanChatSenderC
 // Does the same as Bot.UnbanChatSenderChat, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) UnbanChatSenderChatVoid(ctx context.Context, request *UnbanChatSenderChatRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "unbanChatSenderChat", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "unbanChatSenderChat", request)
 }
 
 // Use this method to set default chat permissions for all members. The bot must be an administrator in the group or a supergroup for this to work and must have the can_restrict_members administrator rights. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setchatpermissions
 func (b *Bot) SetChatPermissions(ctx context.Context, request *SetChatPermissionsRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setChatPermissions", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setChatPermissions", request)
 
 	if err != nil {
 		return r, err
@@ -780,14 +780,14 @@ func (b *Bot) SetChatPermissions(ctx context.Context, request *SetChatPermission
 // Does the same as Bot.SetChatPermissions, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetChatPermissionsVoid(ctx context.Context, request *SetChatPermissionsRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setChatPermissions", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setChatPermissions", request)
 }
 
 // Use this method to generate a new primary invite link for a chat; any previously generated primary link is revoked. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns the new invite link as String on success.
 //
 // https://core.telegram.org/bots/api#exportchatinvitelink
 func (b *Bot) ExportChatInviteLink(ctx context.Context, request *ExportChatInviteLinkRequest) (r string, err error) {
-	res, err := makeRequest[string](ctx, b.Options.Client, b.baseURL, "exportChatInviteLink", b.Options.FloodHandler, request)
+	res, err := makeRequest[string](ctx, b, "exportChatInviteLink", request)
 
 	if err != nil {
 		return r, err
@@ -799,14 +799,14 @@ func (b *Bot) ExportChatInviteLink(ctx context.Context, request *ExportChatInvit
 // Does the same as Bot.ExportChatInviteLink, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) ExportChatInviteLinkVoid(ctx context.Context, request *ExportChatInviteLinkRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "exportChatInviteLink", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "exportChatInviteLink", request)
 }
 
 // Use this method to create an additional invite link for a chat. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. The link can be revoked using the method revokeChatInviteLink. Returns the new invite link as ChatInviteLink object.
 //
 // https://core.telegram.org/bots/api#createchatinvitelink
 func (b *Bot) CreateChatInviteLink(ctx context.Context, request *CreateChatInviteLinkRequest) (r *ChatInviteLink, err error) {
-	res, err := makeRequest[*ChatInviteLink](ctx, b.Options.Client, b.baseURL, "createChatInviteLink", b.Options.FloodHandler, request)
+	res, err := makeRequest[*ChatInviteLink](ctx, b, "createChatInviteLink", request)
 
 	if err != nil {
 		return r, err
@@ -818,14 +818,14 @@ func (b *Bot) CreateChatInviteLink(ctx context.Context, request *CreateChatInvit
 // Does the same as Bot.CreateChatInviteLink, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) CreateChatInviteLinkVoid(ctx context.Context, request *CreateChatInviteLinkRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "createChatInviteLink", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "createChatInviteLink", request)
 }
 
 // Use this method to edit a non-primary invite link created by the bot. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns the edited invite link as a ChatInviteLink object.
 //
 // https://core.telegram.org/bots/api#editchatinvitelink
 func (b *Bot) EditChatInviteLink(ctx context.Context, request *EditChatInviteLinkRequest) (r *ChatInviteLink, err error) {
-	res, err := makeRequest[*ChatInviteLink](ctx, b.Options.Client, b.baseURL, "editChatInviteLink", b.Options.FloodHandler, request)
+	res, err := makeRequest[*ChatInviteLink](ctx, b, "editChatInviteLink", request)
 
 	if err != nil {
 		return r, err
@@ -837,14 +837,14 @@ func (b *Bot) EditChatInviteLink(ctx context.Context, request *EditChatInviteLin
 // Does the same as Bot.EditChatInviteLink, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditChatInviteLinkVoid(ctx context.Context, request *EditChatInviteLinkRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editChatInviteLink", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editChatInviteLink", request)
 }
 
 // Use this method to create a subscription invite link for a channel chat. The bot must have the can_invite_users administrator rights. The link can be edited using the method editChatSubscriptionInviteLink or revoked using the method revokeChatInviteLink. Returns the new invite link as a ChatInviteLink object.
 //
 // https://core.telegram.org/bots/api#createchatsubscriptioninvitelink
 func (b *Bot) CreateChatSubscriptionInviteLink(ctx context.Context, request *CreateChatSubscriptionInviteLinkRequest) (r *ChatInviteLink, err error) {
-	res, err := makeRequest[*ChatInviteLink](ctx, b.Options.Client, b.baseURL, "createChatSubscriptionInviteLink", b.Options.FloodHandler, request)
+	res, err := makeRequest[*ChatInviteLink](ctx, b, "createChatSubscriptionInviteLink", request)
 
 	if err != nil {
 		return r, err
@@ -856,14 +856,14 @@ func (b *Bot) CreateChatSubscriptionInviteLink(ctx context.Context, request *Cre
 // Does the same as Bot.CreateChatSubscriptionInviteLink, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) CreateChatSubscriptionInviteLinkVoid(ctx context.Context, request *CreateChatSubscriptionInviteLinkRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "createChatSubscriptionInviteLink", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "createChatSubscriptionInviteLink", request)
 }
 
 // Use this method to edit a subscription invite link created by the bot. The bot must have the can_invite_users administrator rights. Returns the edited invite link as a ChatInviteLink object.
 //
 // https://core.telegram.org/bots/api#editchatsubscriptioninvitelink
 func (b *Bot) EditChatSubscriptionInviteLink(ctx context.Context, request *EditChatSubscriptionInviteLinkRequest) (r *ChatInviteLink, err error) {
-	res, err := makeRequest[*ChatInviteLink](ctx, b.Options.Client, b.baseURL, "editChatSubscriptionInviteLink", b.Options.FloodHandler, request)
+	res, err := makeRequest[*ChatInviteLink](ctx, b, "editChatSubscriptionInviteLink", request)
 
 	if err != nil {
 		return r, err
@@ -875,14 +875,14 @@ func (b *Bot) EditChatSubscriptionInviteLink(ctx context.Context, request *EditC
 // Does the same as Bot.EditChatSubscriptionInviteLink, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditChatSubscriptionInviteLinkVoid(ctx context.Context, request *EditChatSubscriptionInviteLinkRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editChatSubscriptionInviteLink", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editChatSubscriptionInviteLink", request)
 }
 
 // Use this method to revoke an invite link created by the bot. If the primary link is revoked, a new link is automatically generated. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns the revoked invite link as ChatInviteLink object.
 //
 // https://core.telegram.org/bots/api#revokechatinvitelink
 func (b *Bot) RevokeChatInviteLink(ctx context.Context, request *RevokeChatInviteLinkRequest) (r *ChatInviteLink, err error) {
-	res, err := makeRequest[*ChatInviteLink](ctx, b.Options.Client, b.baseURL, "revokeChatInviteLink", b.Options.FloodHandler, request)
+	res, err := makeRequest[*ChatInviteLink](ctx, b, "revokeChatInviteLink", request)
 
 	if err != nil {
 		return r, err
@@ -894,14 +894,14 @@ func (b *Bot) RevokeChatInviteLink(ctx context.Context, request *RevokeChatInvit
 // Does the same as Bot.RevokeChatInviteLink, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) RevokeChatInviteLinkVoid(ctx context.Context, request *RevokeChatInviteLinkRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "revokeChatInviteLink", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "revokeChatInviteLink", request)
 }
 
 // Use this method to approve a chat join request. The bot must be an administrator in the chat for this to work and must have the can_invite_users administrator right. Returns True on success.
 //
 // https://core.telegram.org/bots/api#approvechatjoinrequest
 func (b *Bot) ApproveChatJoinRequest(ctx context.Context, request *ApproveChatJoinRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "approveChatJoinRequest", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "approveChatJoinRequest", request)
 
 	if err != nil {
 		return r, err
@@ -913,14 +913,14 @@ func (b *Bot) ApproveChatJoinRequest(ctx context.Context, request *ApproveChatJo
 // Does the same as Bot.ApproveChatJoinRequest, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) ApproveChatJoinRequestVoid(ctx context.Context, request *ApproveChatJoinRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "approveChatJoinRequest", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "approveChatJoinRequest", request)
 }
 
 // Use this method to decline a chat join request. The bot must be an administrator in the chat for this to work and must have the can_invite_users administrator right. Returns True on success.
 //
 // https://core.telegram.org/bots/api#declinechatjoinrequest
 func (b *Bot) DeclineChatJoinRequest(ctx context.Context, request *DeclineChatJoinRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "declineChatJoinRequest", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "declineChatJoinRequest", request)
 
 	if err != nil {
 		return r, err
@@ -932,14 +932,14 @@ func (b *Bot) DeclineChatJoinRequest(ctx context.Context, request *DeclineChatJo
 // Does the same as Bot.DeclineChatJoinRequest, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeclineChatJoinRequestVoid(ctx context.Context, request *DeclineChatJoinRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "declineChatJoinRequest", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "declineChatJoinRequest", request)
 }
 
 // Use this method to set a new profile photo for the chat. Photos can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setchatphoto
 func (b *Bot) SetChatPhoto(ctx context.Context, request *SetChatPhotoRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setChatPhoto", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setChatPhoto", request)
 
 	if err != nil {
 		return r, err
@@ -951,14 +951,14 @@ func (b *Bot) SetChatPhoto(ctx context.Context, request *SetChatPhotoRequest) (r
 // Does the same as Bot.SetChatPhoto, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetChatPhotoVoid(ctx context.Context, request *SetChatPhotoRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setChatPhoto", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setChatPhoto", request)
 }
 
 // Use this method to delete a chat photo. Photos can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.
 //
 // https://core.telegram.org/bots/api#deletechatphoto
 func (b *Bot) DeleteChatPhoto(ctx context.Context, request *DeleteChatPhotoRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "deleteChatPhoto", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "deleteChatPhoto", request)
 
 	if err != nil {
 		return r, err
@@ -970,14 +970,14 @@ func (b *Bot) DeleteChatPhoto(ctx context.Context, request *DeleteChatPhotoReque
 // Does the same as Bot.DeleteChatPhoto, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeleteChatPhotoVoid(ctx context.Context, request *DeleteChatPhotoRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "deleteChatPhoto", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "deleteChatPhoto", request)
 }
 
 // Use this method to change the title of a chat. Titles can't be changed for private chats. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setchattitle
 func (b *Bot) SetChatTitle(ctx context.Context, request *SetChatTitleRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setChatTitle", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setChatTitle", request)
 
 	if err != nil {
 		return r, err
@@ -989,14 +989,14 @@ func (b *Bot) SetChatTitle(ctx context.Context, request *SetChatTitleRequest) (r
 // Does the same as Bot.SetChatTitle, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetChatTitleVoid(ctx context.Context, request *SetChatTitleRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setChatTitle", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setChatTitle", request)
 }
 
 // Use this method to change the description of a group, a supergroup or a channel. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setchatdescription
 func (b *Bot) SetChatDescription(ctx context.Context, request *SetChatDescriptionRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setChatDescription", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setChatDescription", request)
 
 	if err != nil {
 		return r, err
@@ -1008,14 +1008,14 @@ func (b *Bot) SetChatDescription(ctx context.Context, request *SetChatDescriptio
 // Does the same as Bot.SetChatDescription, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetChatDescriptionVoid(ctx context.Context, request *SetChatDescriptionRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setChatDescription", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setChatDescription", request)
 }
 
 // Use this method to add a message to the list of pinned messages in a chat. In private chats and channel direct messages chats, all non-service messages can be pinned. Conversely, the bot must be an administrator with the 'can_pin_messages' right or the 'can_edit_messages' right to pin messages in groups and channels respectively. Returns True on success.
 //
 // https://core.telegram.org/bots/api#pinchatmessage
 func (b *Bot) PinChatMessage(ctx context.Context, request *PinChatMessageRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "pinChatMessage", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "pinChatMessage", request)
 
 	if err != nil {
 		return r, err
@@ -1027,14 +1027,14 @@ func (b *Bot) PinChatMessage(ctx context.Context, request *PinChatMessageRequest
 // Does the same as Bot.PinChatMessage, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) PinChatMessageVoid(ctx context.Context, request *PinChatMessageRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "pinChatMessage", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "pinChatMessage", request)
 }
 
 // Use this method to remove a message from the list of pinned messages in a chat. In private chats and channel direct messages chats, all messages can be unpinned. Conversely, the bot must be an administrator with the 'can_pin_messages' right or the 'can_edit_messages' right to unpin messages in groups and channels respectively. Returns True on success.
 //
 // https://core.telegram.org/bots/api#unpinchatmessage
 func (b *Bot) UnpinChatMessage(ctx context.Context, request *UnpinChatMessageRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "unpinChatMessage", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "unpinChatMessage", request)
 
 	if err != nil {
 		return r, err
@@ -1046,14 +1046,14 @@ func (b *Bot) UnpinChatMessage(ctx context.Context, request *UnpinChatMessageReq
 // Does the same as Bot.UnpinChatMessage, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) UnpinChatMessageVoid(ctx context.Context, request *UnpinChatMessageRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "unpinChatMessage", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "unpinChatMessage", request)
 }
 
 // Use this method to clear the list of pinned messages in a chat. In private chats and channel direct messages chats, no additional rights are required to unpin all pinned messages. Conversely, the bot must be an administrator with the 'can_pin_messages' right or the 'can_edit_messages' right to unpin all pinned messages in groups and channels respectively. Returns True on success.
 //
 // https://core.telegram.org/bots/api#unpinallchatmessages
 func (b *Bot) UnpinAllChatMessages(ctx context.Context, request *UnpinAllChatMessagesRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "unpinAllChatMessages", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "unpinAllChatMessages", request)
 
 	if err != nil {
 		return r, err
@@ -1065,14 +1065,14 @@ func (b *Bot) UnpinAllChatMessages(ctx context.Context, request *UnpinAllChatMes
 // Does the same as Bot.UnpinAllChatMessages, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) UnpinAllChatMessagesVoid(ctx context.Context, request *UnpinAllChatMessagesRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "unpinAllChatMessages", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "unpinAllChatMessages", request)
 }
 
 // Use this method for your bot to leave a group, supergroup or channel. Returns True on success.
 //
 // https://core.telegram.org/bots/api#leavechat
 func (b *Bot) LeaveChat(ctx context.Context, request *LeaveChatRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "leaveChat", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "leaveChat", request)
 
 	if err != nil {
 		return r, err
@@ -1084,14 +1084,14 @@ func (b *Bot) LeaveChat(ctx context.Context, request *LeaveChatRequest) (r bool,
 // Does the same as Bot.LeaveChat, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) LeaveChatVoid(ctx context.Context, request *LeaveChatRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "leaveChat", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "leaveChat", request)
 }
 
 // Use this method to get up-to-date information about the chat. Returns a ChatFullInfo object on success.
 //
 // https://core.telegram.org/bots/api#getchat
 func (b *Bot) GetChat(ctx context.Context, request *GetChatRequest) (r *ChatFullInfo, err error) {
-	res, err := makeRequest[*ChatFullInfo](ctx, b.Options.Client, b.baseURL, "getChat", b.Options.FloodHandler, request)
+	res, err := makeRequest[*ChatFullInfo](ctx, b, "getChat", request)
 
 	if err != nil {
 		return r, err
@@ -1104,7 +1104,7 @@ func (b *Bot) GetChat(ctx context.Context, request *GetChatRequest) (r *ChatFull
 //
 // https://core.telegram.org/bots/api#getchatadministrators
 func (b *Bot) GetChatAdministrators(ctx context.Context, request *GetChatAdministratorsRequest) (r []ChatMember, err error) {
-	res, err := makeRequest[[]ChatMember](ctx, b.Options.Client, b.baseURL, "getChatAdministrators", b.Options.FloodHandler, request)
+	res, err := makeRequest[[]ChatMember](ctx, b, "getChatAdministrators", request)
 
 	if err != nil {
 		return r, err
@@ -1117,7 +1117,7 @@ func (b *Bot) GetChatAdministrators(ctx context.Context, request *GetChatAdminis
 //
 // https://core.telegram.org/bots/api#getchatmembercount
 func (b *Bot) GetChatMemberCount(ctx context.Context, request *GetChatMemberCountRequest) (r int, err error) {
-	res, err := makeRequest[int](ctx, b.Options.Client, b.baseURL, "getChatMemberCount", b.Options.FloodHandler, request)
+	res, err := makeRequest[int](ctx, b, "getChatMemberCount", request)
 
 	if err != nil {
 		return r, err
@@ -1130,7 +1130,7 @@ func (b *Bot) GetChatMemberCount(ctx context.Context, request *GetChatMemberCoun
 //
 // https://core.telegram.org/bots/api#getchatmember
 func (b *Bot) GetChatMember(ctx context.Context, request *GetChatMemberRequest) (r *ChatMember, err error) {
-	res, err := makeRequest[*ChatMember](ctx, b.Options.Client, b.baseURL, "getChatMember", b.Options.FloodHandler, request)
+	res, err := makeRequest[*ChatMember](ctx, b, "getChatMember", request)
 
 	if err != nil {
 		return r, err
@@ -1143,7 +1143,7 @@ func (b *Bot) GetChatMember(ctx context.Context, request *GetChatMemberRequest)
 //
 // https://core.telegram.org/bots/api#setchatstickerset
 func (b *Bot) SetChatStickerSet(ctx context.Context, request *SetChatStickerSetRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setChatStickerSet", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setChatStickerSet", request)
 
 	if err != nil {
 		return r, err
@@ -1155,14 +1155,14 @@ func (b *Bot) SetChatStickerSet(ctx context.Context, request *SetChatStickerSetR
 // Does the same as Bot.SetChatStickerSet, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetChatStickerSetVoid(ctx context.Context, request *SetChatStickerSetRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setChatStickerSet", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setChatStickerSet", request)
 }
 
 // Use this method to delete a group sticker set from a supergroup. The bot must be an administrator in the chat for this to work and must have the appropriate administrator rights. Use the field can_set_sticker_set optionally returned in getChat requests to check if the bot can use this method. Returns True on success.
 //
 // https://core.telegram.org/bots/api#deletechatstickerset
 func (b *Bot) DeleteChatStickerSet(ctx context.Context, request *DeleteChatStickerSetRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "deleteChatStickerSet", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "deleteChatStickerSet", request)
 
 	if err != nil {
 		return r, err
@@ -1174,14 +1174,14 @@ func (b *Bot) DeleteChatStickerSet(ctx context.Context, request *DeleteChatStick
 // Does the same as Bot.DeleteChatStickerSet, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeleteChatStickerSetVoid(ctx context.Context, request *DeleteChatStickerSetRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "deleteChatStickerSet", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "deleteChatStickerSet", request)
 }
 
 // Use this method to get custom emoji stickers, which can be used as a forum topic icon by any user. Requires no parameters. Returns an Array of Sticker objects.
 //
 // https://core.telegram.org/bots/api#getforumtopiciconstickers
 func (b *Bot) GetForumTopicIconStickers(ctx context.Context) (r []Sticker, err error) {
-	res, err := makeRequest[[]Sticker](ctx, b.Options.Client, b.baseURL, "getForumTopicIconStickers", b.Options.FloodHandler, nil)
+	res, err := makeRequest[[]Sticker](ctx, b, "getForumTopicIconStickers", nil)
 
 	if err != nil {
 		return r, err
@@ -1194,7 +1194,7 @@ func (b *Bot) GetForumTopicIconStickers(ctx context.Context) (r []Sticker, err e
 //
 // https://core.telegram.org/bots/api#createforumtopic
 func (b *Bot) CreateForumTopic(ctx context.Context, request *CreateForumTopicRequest) (r *ForumTopic, err error) {
-	res, err := makeRequest[*ForumTopic](ctx, b.Options.Client, b.baseURL, "createForumTopic", b.Options.FloodHandler, request)
+	res, err := makeRequest[*ForumTopic](ctx, b, "createForumTopic", request)
 
 	if err != nil {
 		return r, err
@@ -1206,14 +1206,14 @@ func (b *Bot) CreateForumTopic(ctx context.Context, request *CreateForumTopicReq
 // Does the same as Bot.CreateForumTopic, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) CreateForumTopicVoid(ctx context.Context, request *CreateForumTopicRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "createForumTopic", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "createForumTopic", request)
 }
 
 // Use this method to edit name and icon of a topic in a forum supergroup chat or a private chat with a user. In the case of a supergroup chat the bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights, unless it is the creator of the topic. Returns True on success.
 //
 // https://core.telegram.org/bots/api#editforumtopic
 func (b *Bot) EditForumTopic(ctx context.Context, request *EditForumTopicRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "editForumTopic", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "editForumTopic", request)
 
 	if err != nil {
 		return r, err
@@ -1225,14 +1225,14 @@ func (b *Bot) EditForumTopic(ctx context.Context, request *EditForumTopicRequest
 // Does the same as Bot.EditForumTopic, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditForumTopicVoid(ctx context.Context, request *EditForumTopicRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editForumTopic", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editForumTopic", request)
 }
 
 // Use this method to close an open topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights, unless it is the creator of the topic. Returns True on success.
 //
 // https://core.telegram.org/bots/api#closeforumtopic
 func (b *Bot) CloseForumTopic(ctx context.Context, request *CloseForumTopicRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "closeForumTopic", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "closeForumTopic", request)
 
 	if err != nil {
 		return r, err
@@ -1244,14 +1244,14 @@ func (b *Bot) CloseForumTopic(ctx context.Context, request *CloseForumTopicReque
 // Does the same as Bot.CloseForumTopic, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) CloseForumTopicVoid(ctx context.Context, request *CloseForumTopicRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "closeForumTopic", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "closeForumTopic", request)
 }
 
 // Use this method to reopen a closed topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights, unless it is the creator of the topic. Returns True on success.
 //
 // https://core.telegram.org/bots/api#reopenforumtopic
 func (b *Bot) ReopenForumTopic(ctx context.Context, request *ReopenForumTopicRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "reopenForumTopic", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "reopenForumTopic", request)
 
 	if err != nil {
 		return r, err
@@ -1263,14 +1263,14 @@ func (b *Bot) ReopenForumTopic(ctx context.Context, request *ReopenForumTopicReq
 // Does the same as Bot.ReopenForumTopic, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) ReopenForumTopicVoid(ctx context.Context, request *ReopenForumTopicRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "reopenForumTopic", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "reopenForumTopic", request)
 }
 
 // Use this method to delete a forum topic along with all its messages in a forum supergroup chat or a private chat with a user. In the case of a supergroup chat the bot must be an administrator in the chat for this to work and must have the can_delete_messages administrator rights. Returns True on success.
 //
 // https://core.telegram.org/bots/api#deleteforumtopic
 func (b *Bot) DeleteForumTopic(ctx context.Context, request *DeleteForumTopicRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "deleteForumTopic", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "deleteForumTopic", request)
 
 	if err != nil {
 		return r, err
@@ -1282,14 +1282,14 @@ func (b *Bot) DeleteForumTopic(ctx context.Context, request *DeleteForumTopicReq
 // Does the same as Bot.DeleteForumTopic, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeleteForumTopicVoid(ctx context.Context, request *DeleteForumTopicRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "deleteForumTopic", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "deleteForumTopic", request)
 }
 
 // Use this method to clear the list of pinned messages in a forum topic in a forum supergroup chat or a private chat with a user. In the case of a supergroup chat the bot must be an administrator in the chat for this to work and must have the can_pin_messages administrator right in the supergroup. Returns True on success.
 //
 // https://core.telegram.org/bots/api#unpinallforumtopicmessages
 func (b *Bot) UnpinAllForumTopicMessages(ctx context.Context, request *UnpinAllForumTopicMessagesRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "unpinAllForumTopicMessages", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "unpinAllForumTopicMessages", request)
 
 	if err != nil {
 		return r, err
@@ -1301,14 +1301,14 @@ func (b *Bot) UnpinAllForumTopicMessages(ctx context.Context, request *UnpinAllF
 // Does the same as Bot.UnpinAllForumTopicMessages, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) UnpinAllForumTopicMessagesVoid(ctx context.Context, request *UnpinAllForumTopicMessagesRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "unpinAllForumTopicMessages", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "unpinAllForumTopicMessages", request)
 }
 
 // Use this method to edit the name of the 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. Returns True on success.
 //
 // https://core.telegram.org/bots/api#editgeneralforumtopic
 func (b *Bot) EditGeneralForumTopic(ctx context.Context, request *EditGeneralForumTopicRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "editGeneralForumTopic", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "editGeneralForumTopic", request)
 
 	if err != nil {
 		return r, err
@@ -1320,14 +1320,14 @@ func (b *Bot) EditGeneralForumTopic(ctx context.Context, request *EditGeneralFor
 // Does the same as Bot.EditGeneralForumTopic, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditGeneralForumTopicVoid(ctx context.Context, request *EditGeneralForumTopicRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editGeneralForumTopic", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editGeneralForumTopic", request)
 }
 
 // Use this method to close an open 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. Returns True on success.
 //
 // https://core.telegram.org/bots/api#closegeneralforumtopic
 func (b *Bot) CloseGeneralForumTopic(ctx context.Context, request *CloseGeneralForumTopicRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "closeGeneralForumTopic", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "closeGeneralForumTopic", request)
 
 	if err != nil {
 		return r, err
@@ -1339,14 +1339,14 @@ func (b *Bot) CloseGeneralForumTopic(ctx context.Context, request *CloseGeneralF
 // Does the same as Bot.CloseGeneralForumTopic, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) CloseGeneralForumTopicVoid(ctx context.Context, request *CloseGeneralForumTopicRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "closeGeneralForumTopic", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "closeGeneralForumTopic", request)
 }
 
 // Use this method to reopen a closed 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. The topic will be automatically unhidden if it was hidden. Returns True on success.
 //
 // https://core.telegram.org/bots/api#reopengeneralforumtopic
 func (b *Bot) ReopenGeneralForumTopic(ctx context.Context, request *ReopenGeneralForumTopicRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "reopenGeneralForumTopic", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "reopenGeneralForumTopic", request)
 
 	if err != nil {
 		return r, err
@@ -1358,14 +1358,14 @@ func (b *Bot) ReopenGeneralForumTopic(ctx context.Context, request *ReopenGenera
 // Does the same as Bot.ReopenGeneralForumTopic, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) ReopenGeneralForumTopicVoid(ctx context.Context, request *ReopenGeneralForumTopicRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "reopenGeneralForumTopic", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "reopenGeneralForumTopic", request)
 }
 
 // Use this method to hide the 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. The topic will be automatically closed if it was open. Returns True on success.
 //
 // https://core.telegram.org/bots/api#hidegeneralforumtopic
 func (b *Bot) HideGeneralForumTopic(ctx context.Context, request *HideGeneralForumTopicRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "hideGeneralForumTopic", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "hideGeneralForumTopic", request)
 
 	if err != nil {
 		return r, err
@@ -1377,14 +1377,14 @@ func (b *Bot) HideGeneralForumTopic(ctx context.Context, request *HideGeneralFor
 // Does the same as Bot.HideGeneralForumTopic, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) HideGeneralForumTopicVoid(ctx context.Context, request *HideGeneralForumTopicRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "hideGeneralForumTopic", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "hideGeneralForumTopic", request)
 }
 
 // Use this method to unhide the 'General' topic in a forum supergroup chat. The bot must be an administrator in the chat for this to work and must have the can_manage_topics administrator rights. Returns True on success.
 //
 // https://core.telegram.org/bots/api#unhidegeneralforumtopic
 func (b *Bot) UnhideGeneralForumTopic(ctx context.Context, request *UnhideGeneralForumTopicRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "unhideGeneralForumTopic", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "unhideGeneralForumTopic", request)
 
 	if err != nil {
 		return r, err
@@ -1396,14 +1396,14 @@ func (b *Bot) UnhideGeneralForumTopic(ctx context.Context, request *UnhideGenera
 // Does the same as Bot.UnhideGeneralForumTopic, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) UnhideGeneralForumTopicVoid(ctx context.Context, request *UnhideGeneralForumTopicRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "unhideGeneralForumTopic", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "unhideGeneralForumTopic", request)
 }
 
 // Use this method to clear the list of pinned messages in a General forum topic. The bot must be an administrator in the chat for this to work and must have the can_pin_messages administrator right in the supergroup. Returns True on success.
 //
 // https://core.telegram.org/bots/api#unpinallgeneralforumtopicmessages
 func (b *Bot) UnpinAllGeneralForumTopicMessages(ctx context.Context, request *UnpinAllGeneralForumTopicMessagesRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "unpinAllGeneralForumTopicMessages", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "unpinAllGeneralForumTopicMessages", request)
 
 	if err != nil {
 		return r, err
@@ -1415,14 +1415,14 @@ func (b *Bot) UnpinAllGeneralForumTopicMessages(ctx context.Context, request *Un
 // Does the same as Bot.UnpinAllGeneralForumTopicMessages, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) UnpinAllGeneralForumTopicMessagesVoid(ctx context.Context, request *UnpinAllGeneralForumTopicMessagesRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "unpinAllGeneralForumTopicMessages", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "unpinAllGeneralForumTopicMessages", request)
 }
 
 // Use this method to send answers to callback queries sent from inline keyboards. The answer will be displayed to the user as a notification at the top of the chat screen or as an alert. On success, True is returned.
 //
 // https://core.telegram.org/bots/api#answercallbackquery
 func (b *Bot) AnswerCallbackQuery(ctx context.Context, request *AnswerCallbackQueryRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "answerCallbackQuery", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "answerCallbackQuery", request)
 
 	if err != nil {
 		return r, err
@@ -1434,14 +1434,14 @@ func (b *Bot) AnswerCallbackQuery(ctx context.Context, request *AnswerCallbackQu
 // Does the same as Bot.AnswerCallbackQuery, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) AnswerCallbackQueryVoid(ctx context.Context, request *AnswerCallbackQueryRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "answerCallbackQuery", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "answerCallbackQuery", request)
 }
 
 // Use this method to get the list of boosts added to a chat by a user. Requires administrator rights in the chat. Returns a UserChatBoosts object.
 //
 // https://core.telegram.org/bots/api#getuserchatboosts
 func (b *Bot) GetUserChatBoosts(ctx context.Context, request *GetUserChatBoostsRequest) (r *UserChatBoosts, err error) {
-	res, err := makeRequest[*UserChatBoosts](ctx, b.Options.Client, b.baseURL, "getUserChatBoosts", b.Options.FloodHandler, request)
+	res, err := makeRequest[*UserChatBoosts](ctx, b, "getUserChatBoosts", request)
 
 	if err != nil {
 		return r, err
@@ -1454,7 +1454,7 @@ func (b *Bot) GetUserChatBoosts(ctx context.Context, request *GetUserChatBoostsR
 //
 // https://core.telegram.org/bots/api#getbusinessconnection
 func (b *Bot) GetBusinessConnection(ctx context.Context, request *GetBusinessConnectionRequest) (r *BusinessConnection, err error) {
-	res, err := makeRequest[*BusinessConnection](ctx, b.Options.Client, b.baseURL, "getBusinessConnection", b.Options.FloodHandler, request)
+	res, err := makeRequest[*BusinessConnection](ctx, b, "getBusinessConnection", request)
 
 	if err != nil {
 		return r, err
@@ -1467,7 +1467,7 @@ func (b *Bot) GetBusinessConnection(ctx context.Context, request *GetBusinessCon
 //
 // https://core.telegram.org/bots/api#setmycommands
 func (b *Bot) SetMyCommands(ctx context.Context, request *SetMyCommandsRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setMyCommands", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setMyCommands", request)
 
 	if err != nil {
 		return r, err
@@ -1479,14 +1479,14 @@ func (b *Bot) SetMyCommands(ctx context.Context, request *SetMyCommandsRequest)
 // Does the same as Bot.SetMyCommands, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetMyCommandsVoid(ctx context.Context, request *SetMyCommandsRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setMyCommands", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setMyCommands", request)
 }
 
 // Use this method to delete the list of the bot's commands for the given scope and user language. After deletion, higher level commands will be shown to affected users. Returns True on success.
 //
 // https://core.telegram.org/bots/api#deletemycommands
 func (b *Bot) DeleteMyCommands(ctx context.Context, request *DeleteMyCommandsRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "deleteMyCommands", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "deleteMyCommands", request)
 
 	if err != nil {
 		return r, err
@@ -1498,14 +1498,14 @@ func (b *Bot) DeleteMyCommands(ctx context.Context, request *DeleteMyCommandsReq
 // Does the same as Bot.DeleteMyCommands, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeleteMyCommandsVoid(ctx context.Context, request *DeleteMyCommandsRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "deleteMyCommands", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "deleteMyCommands", request)
 }
 
 // Use this method to get the current list of the bot's commands for the given scope and user language. Returns an Array of BotCommand objects. If commands aren't set, an empty list is returned.
 //
 // https://core.telegram.org/bots/api#getmycommands
 func (b *Bot) GetMyCommands(ctx context.Context, request *GetMyCommandsRequest) (r []BotCommand, err error) {
-	res, err := makeRequest[[]BotCommand](ctx, b.Options.Client, b.baseURL, "getMyCommands", b.Options.FloodHandler, request)
+	res, err := makeRequest[[]BotCommand](ctx, b, "getMyCommands", request)
 
 	if err != nil {
 		return r, err
@@ -1518,7 +1518,7 @@ func (b *Bot) GetMyCommands(ctx context.Context, request *GetMyCommandsRequest)
 //
 // https://core.telegram.org/bots/api#setmyname
 func (b *Bot) SetMyName(ctx context.Context, request *SetMyNameRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setMyName", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setMyName", request)
 
 	if err != nil {
 		return r, err
@@ -1530,14 +1530,14 @@ func (b *Bot) SetMyName(ctx context.Context, request *SetMyNameRequest) (r bool,
 // Does the same as Bot.SetMyName, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetMyNameVoid(ctx context.Context, request *SetMyNameRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setMyName", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setMyName", request)
 }
 
 // Use this method to get the current bot name for the given user language. Returns BotName on success.
 //
 // https://core.telegram.org/bots/api#getmyname
 func (b *Bot) GetMyName(ctx context.Context, request *GetMyNameRequest) (r *BotName, err error) {
-	res, err := makeRequest[*BotName](ctx, b.Options.Client, b.baseURL, "getMyName", b.Options.FloodHandler, request)
+	res, err := makeRequest[*BotName](ctx, b, "getMyName", request)
 
 	if err != nil {
 		return r, err
@@ -1550,7 +1550,7 @@ func (b *Bot) GetMyName(ctx context.Context, request *GetMyNameRequest) (r *BotN
 //
 // https://core.telegram.org/bots/api#setmydescription
 func (b *Bot) SetMyDescription(ctx context.Context, request *SetMyDescriptionRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setMyDescription", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setMyDescription", request)
 
 	if err != nil {
 		return r, err
@@ -1562,14 +1562,14 @@ func (b *Bot) SetMyDescription(ctx context.Context, request *SetMyDescriptionReq
 // Does the same as Bot.SetMyDescription, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetMyDescriptionVoid(ctx context.Context, request *SetMyDescriptionRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setMyDescription", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setMyDescription", request)
 }
 
 // Use this method to get the current bot description for the given user language. Returns BotDescription on success.
 //
 // https://core.telegram.org/bots/api#getmydescription
 func (b *Bot) GetMyDescription(ctx context.Context, request *GetMyDescriptionRequest) (r *BotDescription, err error) {
-	res, err := makeRequest[*BotDescription](ctx, b.Options.Client, b.baseURL, "getMyDescription", b.Options.FloodHandler, request)
+	res, err := makeRequest[*BotDescription](ctx, b, "getMyDescription", request)
 
 	if err != nil {
 		return r, err
@@ -1582,7 +1582,7 @@ func (b *Bot) GetMyDescription(ctx context.Context, request *GetMyDescriptionReq
 //
 // https://core.telegram.org/bots/api#setmyshortdescription
 func (b *Bot) SetMyShortDescription(ctx context.Context, request *SetMyShortDescriptionRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setMyShortDescription", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setMyShortDescription", request)
 
 	if err != nil {
 		return r, err
@@ -1594,14 +1594,14 @@ func (b *Bot) SetMyShortDescription(ctx context.Context, request *SetMyShortDesc
 // Does the same as Bot.SetMyShortDescription, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetMyShortDescriptionVoid(ctx context.Context, request *SetMyShortDescriptionRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setMyShortDescription", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setMyShortDescription", request)
 }
 
 // Use this method to get the current bot short description for the given user language. Returns BotShortDescription on success.
 //
 // https://core.telegram.org/bots/api#getmyshortdescription
 func (b *Bot) GetMyShortDescription(ctx context.Context, request *GetMyShortDescriptionRequest) (r *BotShortDescription, err error) {
-	res, err := makeRequest[*BotShortDescription](ctx, b.Options.Client, b.baseURL, "getMyShortDescription", b.Options.FloodHandler, request)
+	res, err := makeRequest[*BotShortDescription](ctx, b, "getMyShortDescription", request)
 
 	if err != nil {
 		return r, err
@@ -1614,7 +1614,7 @@ func (b *Bot) GetMyShortDescription(ctx context.Context, request *GetMyShortDesc
 //
 // https://core.telegram.org/bots/api#setmyprofilephoto
 func (b *Bot) SetMyProfilePhoto(ctx context.Context, request *SetMyProfilePhotoRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setMyProfilePhoto", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setMyProfilePhoto", request)
 
 	if err != nil {
 		return r, err
@@ -1626,14 +1626,14 @@ func (b *Bot) SetMyProfilePhoto(ctx context.Context, request *SetMyProfilePhotoR
 // Does the same as Bot.SetMyProfilePhoto, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetMyProfilePhotoVoid(ctx context.Context, request *SetMyProfilePhotoRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setMyProfilePhoto", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setMyProfilePhoto", request)
 }
 
 // Removes the profile photo of the bot. Requires no parameters. Returns True on success.
 //
 // https://core.telegram.org/bots/api#removemyprofilephoto
 func (b *Bot) RemoveMyProfilePhoto(ctx context.Context) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "removeMyProfilePhoto", b.Options.FloodHandler, nil)
+	res, err := makeRequest[bool](ctx, b, "removeMyProfilePhoto", nil)
 
 	if err != nil {
 		return r, err
@@ -1646,7 +1646,7 @@ func (b *Bot) RemoveMyProfilePhoto(ctx context.Context) (r bool, err error) {
 //
 // https://core.telegram.org/bots/api#setchatmenubutton
 func (b *Bot) SetChatMenuButton(ctx context.Context, request *SetChatMenuButtonRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setChatMenuButton", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setChatMenuButton", request)
 
 	if err != nil {
 		return r, err
@@ -1658,14 +1658,14 @@ func (b *Bot) SetChatMenuButton(ctx context.Context, request *SetChatMenuButtonR
 // Does the same as Bot.SetChatMenuButton, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetChatMenuButtonVoid(ctx context.Context, request *SetChatMenuButtonRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setChatMenuButton", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setChatMenuButton", request)
 }
 
 // Use this method to get the current value of the bot's menu button in a private chat, or the default menu button. Returns MenuButton on success.
 //
 // https://core.telegram.org/bots/api#getchatmenubutton
 func (b *Bot) GetChatMenuButton(ctx context.Context, request *GetChatMenuButtonRequest) (r *MenuButton, err error) {
-	res, err := makeRequest[*MenuButton](ctx, b.Options.Client, b.baseURL, "getChatMenuButton", b.Options.FloodHandler, request)
+	res, err := makeRequest[*MenuButton](ctx, b, "getChatMenuButton", request)
 
 	if err != nil {
 		return r, err
@@ -1678,7 +1678,7 @@ func (b *Bot) GetChatMenuButton(ctx context.Context, request *GetChatMenuButtonR
 //
 // https://core.telegram.org/bots/api#setmydefaultadministratorrights
 func (b *Bot) SetMyDefaultAdministratorRights(ctx context.Context, request *SetMyDefaultAdministratorRightsRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setMyDefaultAdministratorRights", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setMyDefaultAdministratorRights", request)
 
 	if err != nil {
 		return r, err
@@ -1690,14 +1690,14 @@ func (b *Bot) SetMyDefaultAdministratorRights(ctx context.Context, request *SetM
 // Does the same as Bot.SetMyDefaultAdministratorRights, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetMyDefaultAdministratorRightsVoid(ctx context.Context, request *SetMyDefaultAdministratorRightsRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setMyDefaultAdministratorRights", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setMyDefaultAdministratorRights", request)
 }
 
 // Use this method to get the current default administrator rights of the bot. Returns ChatAdministratorRights on success.
 //
 // https://core.telegram.org/bots/api#getmydefaultadministratorrights
 func (b *Bot) GetMyDefaultAdministratorRights(ctx context.Context, request *GetMyDefaultAdministratorRightsRequest) (r *ChatAdministratorRights, err error) {
-	res, err := makeRequest[*ChatAdministratorRights](ctx, b.Options.Client, b.baseURL, "getMyDefaultAdministratorRights", b.Options.FloodHandler, request)
+	res, err := makeRequest[*ChatAdministratorRights](ctx, b, "getMyDefaultAdministratorRights", request)
 
 	if err != nil {
 		return r, err
@@ -1710,7 +1710,7 @@ func (b *Bot) GetMyDefaultAdministratorRights(ctx context.Context, request *GetM
 //
 // https://core.telegram.org/bots/api#getavailablegifts
 func (b *Bot) GetAvailableGifts(ctx context.Context) (r *Gifts, err error) {
-	res, err := makeRequest[*Gifts](ctx, b.Options.Client, b.baseURL, "getAvailableGifts", b.Options.FloodHandler, nil)
+	res, err := makeRequest[*Gifts](ctx, b, "getAvailableGifts", nil)
 
 	if err != nil {
 		return r, err
@@ -1723,7 +1723,7 @@ func (b *Bot) GetAvailableGifts(ctx context.Context) (r *Gifts, err error) {
 //
 // https://core.telegram.org/bots/api#sendgift
 func (b *Bot) SendGift(ctx context.Context, request *SendGiftRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "sendGift", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "sendGift", request)
 
 	if err != nil {
 		return r, err
@@ -1735,14 +1735,14 @@ func (b *Bot) SendGift(ctx context.Context, request *SendGiftRequest) (r bool, e
 // Does the same as Bot.SendGift, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendGiftVoid(ctx context.Context, request *SendGiftRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendGift", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendGift", request)
 }
 
 // Gifts a Telegram Premium subscription to the given user. Returns True on success.
 //
 // https://core.telegram.org/bots/api#giftpremiumsubscription
 func (b *Bot) GiftPremiumSubscription(ctx context.Context, request *GiftPremiumSubscriptionRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "giftPremiumSubscription", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "giftPremiumSubscription", request)
 
 	if err != nil {
 		return r, err
@@ -1754,14 +1754,14 @@ func (b *Bot) GiftPremiumSubscription(ctx context.Context, request *GiftPremiumS
 // Does the same as Bot.GiftPremiumSubscription, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) GiftPremiumSubscriptionVoid(ctx context.Context, request *GiftPremiumSubscriptionRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "giftPremiumSubscription", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "giftPremiumSubscription", request)
 }
 
 // Verifies a user on behalf of the organization which is represented by the bot. Returns True on success.
 //
 // https://core.telegram.org/bots/api#verifyuser
 func (b *Bot) VerifyUser(ctx context.Context, request *VerifyUserRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "verifyUser", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "verifyUser", request)
 
 	if err != nil {
 		return r, err
@@ -1773,14 +1773,14 @@ func (b *Bot) VerifyUser(ctx context.Context, request *VerifyUserRequest) (r boo
 // Does the same as Bot.VerifyUser, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) VerifyUserVoid(ctx context.Context, request *VerifyUserRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "verifyUser", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "verifyUser", request)
 }
 
 // Verifies a chat on behalf of the organization which is represented by the bot. Returns True on success.
 //
 // https://core.telegram.org/bots/api#verifychat
 func (b *Bot) VerifyChat(ctx context.Context, request *VerifyChatRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "verifyChat", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "verifyChat", request)
 
 	if err != nil {
 		return r, err
@@ -1792,14 +1792,14 @@ func (b *Bot) VerifyChat(ctx context.Context, request *VerifyChatRequest) (r boo
 // Does the same as Bot.VerifyChat, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) VerifyChatVoid(ctx context.Context, request *VerifyChatRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "verifyChat", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "verifyChat", request)
 }
 
 // Removes verification from a user who is currently verified on behalf of the organization represented by the bot. Returns True on success.
 //
 // https://core.telegram.org/bots/api#removeuserverification
 func (b *Bot) RemoveUserVerification(ctx context.Context, request *RemoveUserVerificationRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "removeUserVerification", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "removeUserVerification", request)
 
 	if err != nil {
 		return r, err
@@ -1811,14 +1811,14 @@ func (b *Bot) RemoveUserVerification(ctx context.Context, request *RemoveUserVer
 // Does the same as Bot.RemoveUserVerification, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) RemoveUserVerificationVoid(ctx context.Context, request *RemoveUserVerificationRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "removeUserVerification", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "removeUserVerification", request)
 }
 
 // Removes verification from a chat that is currently verified on behalf of the organization represented by the bot. Returns True on success.
 //
 // https://core.telegram.org/bots/api#removechatverification
 func (b *Bot) RemoveChatVerification(ctx context.Context, request *RemoveChatVerificationRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "removeChatVerification", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "removeChatVerification", request)
 
 	if err != nil {
 		return r, err
@@ -1830,14 +1830,14 @@ func (b *Bot) RemoveChatVerification(ctx context.Context, request *RemoveChatVer
 // Does the same as Bot.RemoveChatVerification, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) RemoveChatVerificationVoid(ctx context.Context, request *RemoveChatVerificationRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "removeChatVerification", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "removeChatVerification", request)
 }
 
 // Marks incoming message as read on behalf of a business account. Requires the can_read_messages business bot right. Returns True on success.
 //
 // https://core.telegram.org/bots/api#readbusinessmessage
 func (b *Bot) ReadBusinessMessage(ctx context.Context, request *ReadBusinessMessageRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "readBusinessMessage", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "readBusinessMessage", request)
 
 	if err != nil {
 		return r, err
@@ -1849,14 +1849,14 @@ func (b *Bot) ReadBusinessMessage(ctx context.Context, request *ReadBusinessMess
 // Does the same as Bot.ReadBusinessMessage, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) ReadBusinessMessageVoid(ctx context.Context, request *ReadBusinessMessageRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "readBusinessMessage", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "readBusinessMessage", request)
 }
 
 // Delete messages on behalf of a business account. Requires the can_delete_sent_messages business bot right to delete messages sent by the bot itself, or the can_delete_all_messages business bot right to delete any message. Returns True on success.
 //
 // https://core.telegram.org/bots/api#deletebusinessmessages
 func (b *Bot) DeleteBusinessMessages(ctx context.Context, request *DeleteBusinessMessagesRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "deleteBusinessMessages", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "deleteBusinessMessages", request)
 
 	if err != nil {
 		return r, err
@@ -1868,14 +1868,14 @@ func (b *Bot) DeleteBusinessMessages(ctx context.Context, request *DeleteBusines
 // Does the same as Bot.DeleteBusinessMessages, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeleteBusinessMessagesVoid(ctx context.Context, request *DeleteBusinessMessagesRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "deleteBusinessMessages", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "deleteBusinessMessages", request)
 }
 
 // Changes the first and last name of a managed business account. Requires the can_change_name business bot right. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setbusinessaccountname
 func (b *Bot) SetBusinessAccountName(ctx context.Context, request *SetBusinessAccountNameRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setBusinessAccountName", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setBusinessAccountName", request)
 
 	if err != nil {
 		return r, err
@@ -1887,14 +1887,14 @@ func (b *Bot) SetBusinessAccountName(ctx context.Context, request *SetBusinessAc
 // Does the same as Bot.SetBusinessAccountName, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetBusinessAccountNameVoid(ctx context.Context, request *SetBusinessAccountNameRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setBusinessAccountName", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setBusinessAccountName", request)
 }
 
 // Changes the username of a managed business account. Requires the can_change_username business bot right. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setbusinessaccountusername
 func (b *Bot) SetBusinessAccountUsername(ctx context.Context, request *SetBusinessAccountUsernameRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setBusinessAccountUsername", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setBusinessAccountUsername", request)
 
 	if err != nil {
 		return r, err
@@ -1906,14 +1906,14 @@ func (b *Bot) SetBusinessAccountUsername(ctx context.Context, request *SetBusine
 // Does the same as Bot.SetBusinessAccountUsername, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetBusinessAccountUsernameVoid(ctx context.Context, request *SetBusinessAccountUsernameRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setBusinessAccountUsername", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setBusinessAccountUsername", request)
 }
 
 // Changes the bio of a managed business account. Requires the can_change_bio business bot right. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setbusinessaccountbio
 func (b *Bot) SetBusinessAccountBio(ctx context.Context, request *SetBusinessAccountBioRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setBusinessAccountBio", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setBusinessAccountBio", request)
 
 	if err != nil {
 		return r, err
@@ -1925,14 +1925,14 @@ func (b *Bot) SetBusinessAccountBio(ctx context.Context, request *SetBusinessAcc
 // Does the same as Bot.SetBusinessAccountBio, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetBusinessAccountBioVoid(ctx context.Context, request *SetBusinessAccountBioRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setBusinessAccountBio", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setBusinessAccountBio", request)
 }
 
 // Changes the profile photo of a managed business account. Requires the can_edit_profile_photo business bot right. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setbusinessaccountprofilephoto
 func (b *Bot) SetBusinessAccountProfilePhoto(ctx context.Context, request *SetBusinessAccountProfilePhotoRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setBusinessAccountProfilePhoto", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setBusinessAccountProfilePhoto", request)
 
 	if err != nil {
 		return r, err
@@ -1944,14 +1944,14 @@ func (b *Bot) SetBusinessAccountProfilePhoto(ctx context.Context, request *SetBu
 // Does the same as Bot.SetBusinessAccountProfilePhoto, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetBusinessAccountProfilePhotoVoid(ctx context.Context, request *SetBusinessAccountProfilePhotoRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setBusinessAccountProfilePhoto", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setBusinessAccountProfilePhoto", request)
 }
 
 // Removes the current profile photo of a managed business account. Requires the can_edit_profile_photo business bot right. Returns True on success.
 //
 // https://core.telegram.org/bots/api#removebusinessaccountprofilephoto
 func (b *Bot) RemoveBusinessAccountProfilePhoto(ctx context.Context, request *RemoveBusinessAccountProfilePhotoRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "removeBusinessAccountProfilePhoto", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "removeBusinessAccountProfilePhoto", request)
 
 	if err != nil {
 		return r, err
@@ -1963,14 +1963,14 @@ func (b *Bot) RemoveBusinessAccountProfilePhoto(ctx context.Context, request *Re
 // Does the same as Bot.RemoveBusinessAccountProfilePhoto, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) RemoveBusinessAccountProfilePhotoVoid(ctx context.Context, request *RemoveBusinessAccountProfilePhotoRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "removeBusinessAccountProfilePhoto", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "removeBusinessAccountProfilePhoto", request)
 }
 
 // Changes the privacy settings pertaining to incoming gifts in a managed business account. Requires the can_change_gift_settings business bot right. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setbusinessaccountgiftsettings
 func (b *Bot) SetBusinessAccountGiftSettings(ctx context.Context, request *SetBusinessAccountGiftSettingsRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setBusinessAccountGiftSettings", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setBusinessAccountGiftSettings", request)
 
 	if err != nil {
 		return r, err
@@ -1982,14 +1982,14 @@ func (b *Bot) SetBusinessAccountGiftSettings(ctx context.Context, request *SetBu
 // Does the same as Bot.SetBusinessAccountGiftSettings, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetBusinessAccountGiftSettingsVoid(ctx context.Context, request *SetBusinessAccountGiftSettingsRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setBusinessAccountGiftSettings", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setBusinessAccountGiftSettings", request)
 }
 
 // Returns the amount of Telegram Stars owned by a managed business account. Requires the can_view_gifts_and_stars business bot right. Returns StarAmount on success.
 //
 // https://core.telegram.org/bots/api#getbusinessaccountstarbalance
 func (b *Bot) GetBusinessAccountStarBalance(ctx context.Context, request *GetBusinessAccountStarBalanceRequest) (r *StarAmount, err error) {
-	res, err := makeRequest[*StarAmount](ctx, b.Options.Client, b.baseURL, "getBusinessAccountStarBalance", b.Options.FloodHandler, request)
+	res, err := makeRequest[*StarAmount](ctx, b, "getBusinessAccountStarBalance", request)
 
 	if err != nil {
 		return r, err
@@ -2002,7 +2002,7 @@ func (b *Bot) GetBusinessAccountStarBalance(ctx context.Context, request *GetBus
 //
 // https://core.telegram.org/bots/api#transferbusinessaccountstars
 func (b *Bot) TransferBusinessAccountStars(ctx context.Context, request *TransferBusinessAccountStarsRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "transferBusinessAccountStars", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "transferBusinessAccountStars", request)
 
 	if err != nil {
 		return r, err
@@ -2014,14 +2014,14 @@ func (b *Bot) TransferBusinessAccountStars(ctx context.Context, request *Transfe
 // Does the same as Bot.TransferBusinessAccountStars, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) TransferBusinessAccountStarsVoid(ctx context.Context, request *TransferBusinessAccountStarsRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "transferBusinessAccountStars", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "transferBusinessAccountStars", request)
 }
 
 // Returns the gifts received and owned by a managed business account. Requires the can_view_gifts_and_stars business bot right. Returns OwnedGifts on success.
 //
 // https://core.telegram.org/bots/api#getbusinessaccountgifts
 func (b *Bot) GetBusinessAccountGifts(ctx context.Context, request *GetBusinessAccountGiftsRequest) (r *OwnedGifts, err error) {
-	res, err := makeRequest[*OwnedGifts](ctx, b.Options.Client, b.baseURL, "getBusinessAccountGifts", b.Options.FloodHandler, request)
+	res, err := makeRequest[*OwnedGifts](ctx, b, "getBusinessAccountGifts", request)
 
 	if err != nil {
 		return r, err
@@ -2034,7 +2034,7 @@ func (b *Bot) GetBusinessAccountGifts(ctx context.Context, request *GetBusinessA
 //
 // https://core.telegram.org/bots/api#getusergifts
 func (b *Bot) GetUserGifts(ctx context.Context, request *GetUserGiftsRequest) (r *OwnedGifts, err error) {
-	res, err := makeRequest[*OwnedGifts](ctx, b.Options.Client, b.baseURL, "getUserGifts", b.Options.FloodHandler, request)
+	res, err := makeRequest[*OwnedGifts](ctx, b, "getUserGifts", request)
 
 	if err != nil {
 		return r, err
@@ -2047,7 +2047,7 @@ func (b *Bot) GetUserGifts(ctx context.Context, request *GetUserGiftsRequest) (r
 //
 // https://core.telegram.org/bots/api#getchatgifts
 func (b *Bot) GetChatGifts(ctx context.Context, request *GetChatGiftsRequest) (r *OwnedGifts, err error) {
-	res, err := makeRequest[*OwnedGifts](ctx, b.Options.Client, b.baseURL, "getChatGifts", b.Options.FloodHandler, request)
+	res, err := makeRequest[*OwnedGifts](ctx, b, "getChatGifts", request)
 
 	if err != nil {
 		return r, err
@@ -2060,7 +2060,7 @@ func (b *Bot) GetChatGifts(ctx context.Context, request *GetChatGiftsRequest) (r
 //
 // https://core.telegram.org/bots/api#convertgifttostars
 func (b *Bot) ConvertGiftToStars(ctx context.Context, request *ConvertGiftToStarsRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "convertGiftToStars", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "convertGiftToStars", request)
 
 	if err != nil {
 		return r, err
@@ -2072,14 +2072,14 @@ func (b *Bot) ConvertGiftToStars(ctx context.Context, request *ConvertGiftToStar
 // Does the same as Bot.ConvertGiftToStars, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) ConvertGiftToStarsVoid(ctx context.Context, request *ConvertGiftToStarsRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "convertGiftToStars", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "convertGiftToStars", request)
 }
 
 // Upgrades a given regular gift to a unique gift. Requires the can_transfer_and_upgrade_gifts business bot right. Additionally requires the can_transfer_stars business bot right if the upgrade is paid. Returns True on success.
 //
 // https://core.telegram.org/bots/api#upgradegift
 func (b *Bot) UpgradeGift(ctx context.Context, request *UpgradeGiftRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "upgradeGift", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "upgradeGift", request)
 
 	if err != nil {
 		return r, err
@@ -2091,14 +2091,14 @@ func (b *Bot) UpgradeGift(ctx context.Context, request *UpgradeGiftRequest) (r b
 // Does the same as Bot.UpgradeGift, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) UpgradeGiftVoid(ctx context.Context, request *UpgradeGiftRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "upgradeGift", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "upgradeGift", request)
 }
 
 // Transfers an owned unique gift to another user. Requires the can_transfer_and_upgrade_gifts business bot right. Requires can_transfer_stars business bot right if the transfer is paid. Returns True on success.
 //
 // https://core.telegram.org/bots/api#transfergift
 func (b *Bot) TransferGift(ctx context.Context, request *TransferGiftRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "transferGift", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "transferGift", request)
 
 	if err != nil {
 		return r, err
@@ -2110,14 +2110,14 @@ func (b *Bot) TransferGift(ctx context.Context, request *TransferGiftRequest) (r
 // Does the same as Bot.TransferGift, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) TransferGiftVoid(ctx context.Context, request *TransferGiftRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "transferGift", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "transferGift", request)
 }
 
 // Posts a story on behalf of a managed business account. Requires the can_manage_stories business bot right. Returns Story on success.
 //
 // https://core.telegram.org/bots/api#poststory
 func (b *Bot) PostStory(ctx context.Context, request *PostStoryRequest) (r *Story, err error) {
-	res, err := makeRequest[*Story](ctx, b.Options.Client, b.baseURL, "postStory", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Story](ctx, b, "postStory", request)
 
 	if err != nil {
 		return r, err
@@ -2129,14 +2129,14 @@ func (b *Bot) PostStory(ctx context.Context, request *PostStoryRequest) (r *Stor
 // Does the same as Bot.PostStory, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) PostStoryVoid(ctx context.Context, request *PostStoryRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "postStory", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "postStory", request)
 }
 
 // Reposts a story on behalf of a business account from another business account. Both business accounts must be managed by the same bot, and the story on the source account must have been posted (or reposted) by the bot. Requires the can_manage_stories business bot right for both business accounts. Returns Story on success.
 //
 // https://core.telegram.org/bots/api#repoststory
 func (b *Bot) RepostStory(ctx context.Context, request *RepostStoryRequest) (r *Story, err error) {
-	res, err := makeRequest[*Story](ctx, b.Options.Client, b.baseURL, "repostStory", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Story](ctx, b, "repostStory", request)
 
 	if err != nil {
 		return r, err
@@ -2148,14 +2148,14 @@ func (b *Bot) RepostStory(ctx context.Context, request *RepostStoryRequest) (r *
 // Does the same as Bot.RepostStory, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) RepostStoryVoid(ctx context.Context, request *RepostStoryRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "repostStory", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "repostStory", request)
 }
 
 // Edits a story previously posted by the bot on behalf of a managed business account. Requires the can_manage_stories business bot right. Returns Story on success.
 //
 // https://core.telegram.org/bots/api#editstory
 func (b *Bot) EditStory(ctx context.Context, request *EditStoryRequest) (r *Story, err error) {
-	res, err := makeRequest[*Story](ctx, b.Options.Client, b.baseURL, "editStory", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Story](ctx, b, "editStory", request)
 
 	if err != nil {
 		return r, err
@@ -2167,14 +2167,14 @@ func (b *Bot) EditStory(ctx context.Context, request *EditStoryRequest) (r *Stor
 // Does the same as Bot.EditStory, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditStoryVoid(ctx context.Context, request *EditStoryRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editStory", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editStory", request)
 }
 
 // Deletes a story previously posted by the bot on behalf of a managed business account. Requires the can_manage_stories business bot right. Returns True on success.
 //
 // https://core.telegram.org/bots/api#deletestory
 func (b *Bot) DeleteStory(ctx context.Context, request *DeleteStoryRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "deleteStory", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "deleteStory", request)
 
 	if err != nil {
 		return r, err
@@ -2186,14 +2186,14 @@ func (b *Bot) DeleteStory(ctx context.Context, request *DeleteStoryRequest) (r b
 // Does the same as Bot.DeleteStory, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeleteStoryVoid(ctx context.Context, request *DeleteStoryRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "deleteStory", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "deleteStory", request)
 }
 
 // Use this method to edit text and game messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned. Note that business messages that were not sent by the bot and do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 //
 // https://core.telegram.org/bots/api#editmessagetext
 func (b *Bot) EditMessageText(ctx context.Context, request *EditMessageTextRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "editMessageText", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "editMessageText", request)
 
 	if err != nil {
 		return r, err
@@ -2205,14 +2205,14 @@ func (b *Bot) EditMessageText(ctx context.Context, request *EditMessageTextReque
 // Does the same as Bot.EditMessageText, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditMessageTextVoid(ctx context.Context, request *EditMessageTextRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editMessageText", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editMessageText", request)
 }
 
 // Use this method to edit captions of messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned. Note that business messages that were not sent by the bot and do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 //
 // https://core.telegram.org/bots/api#editmessagecaption
 func (b *Bot) EditMessageCaption(ctx context.Context, request *EditMessageCaptionRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "editMessageCaption", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "editMessageCaption", request)
 
 	if err != nil {
 		return r, err
@@ -2224,14 +2224,14 @@ func (b *Bot) EditMessageCaption(ctx context.Context, request *EditMessageCaptio
 // Does the same as Bot.EditMessageCaption, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditMessageCaptionVoid(ctx context.Context, request *EditMessageCaptionRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editMessageCaption", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editMessageCaption", request)
 }
 
 // Use this method to edit animation, audio, document, photo, or video messages, or to add media to text messages. If a message is part of a message album, then it can be edited only to an audio for audio albums, only to a document for document albums and to a photo or a video otherwise. When an inline message is edited, a new file can't be uploaded; use a previously uploaded file via its file_id or specify a URL. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned. Note that business messages that were not sent by the bot and do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 //
 // https://core.telegram.org/bots/api#editmessagemedia
 func (b *Bot) EditMessageMedia(ctx context.Context, request *EditMessageMediaRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "editMessageMedia", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "editMessageMedia", request)
 
 	if err != nil {
 		return r, err
@@ -2243,14 +2243,14 @@ func (b *Bot) EditMessageMedia(ctx context.Context, request *EditMessageMediaReq
 // Does the same as Bot.EditMessageMedia, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditMessageMediaVoid(ctx context.Context, request *EditMessageMediaRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editMessageMedia", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editMessageMedia", request)
 }
 
 // Use this method to edit live location messages. A location can be edited until its live_period expires or editing is explicitly disabled by a call to stopMessageLiveLocation. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned.
 //
 // https://core.telegram.org/bots/api#editmessagelivelocation
 func (b *Bot) EditMessageLiveLocation(ctx context.Context, request *EditMessageLiveLocationRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "editMessageLiveLocation", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "editMessageLiveLocation", request)
 
 	if err != nil {
 		return r, err
@@ -2262,14 +2262,14 @@ func (b *Bot) EditMessageLiveLocation(ctx context.Context, request *EditMessageL
 // Does the same as Bot.EditMessageLiveLocation, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditMessageLiveLocationVoid(ctx context.Context, request *EditMessageLiveLocationRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editMessageLiveLocation", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editMessageLiveLocation", request)
 }
 
 // Use this method to stop updating a live location message before live_period expires. On success, if the message is not an inline message, the edited Message is returned, otherwise True is returned.
 //
 // https://core.telegram.org/bots/api#stopmessagelivelocation
 func (b *Bot) StopMessageLiveLocation(ctx context.Context, request *StopMessageLiveLocationRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "stopMessageLiveLocation", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "stopMessageLiveLocation", request)
 
 	if err != nil {
 		return r, err
@@ -2281,14 +2281,14 @@ func (b *Bot) StopMessageLiveLocation(ctx context.Context, request *StopMessageL
 // Does the same as Bot.StopMessageLiveLocation, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) StopMessageLiveLocationVoid(ctx context.Context, request *StopMessageLiveLocationRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "stopMessageLiveLocation", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "stopMessageLiveLocation", request)
 }
 
 // Use this method to edit a checklist on behalf of a connected business account. On success, the edited Message is returned.
 //
 // https://core.telegram.org/bots/api#editmessagechecklist
 func (b *Bot) EditMessageChecklist(ctx context.Context, request *EditMessageChecklistRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "editMessageChecklist", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "editMessageChecklist", request)
 
 	if err != nil {
 		return r, err
@@ -2300,14 +2300,14 @@ func (b *Bot) EditMessageChecklist(ctx context.Context, request *EditMessageChec
 // Does the same as Bot.EditMessageChecklist, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditMessageChecklistVoid(ctx context.Context, request *EditMessageChecklistRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editMessageChecklist", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editMessageChecklist", request)
 }
 
 // Use this method to edit only the reply markup of messages. On success, if the edited message is not an inline message, the edited Message is returned, otherwise True is returned. Note that business messages that were not sent by the bot and do not contain an inline keyboard can only be edited within 48 hours from the time they were sent.
 //
 // https://core.telegram.org/bots/api#editmessagereplymarkup
 func (b *Bot) EditMessageReplyMarkup(ctx context.Context, request *EditMessageReplyMarkupRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "editMessageReplyMarkup", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "editMessageReplyMarkup", request)
 
 	if err != nil {
 		return r, err
@@ -2319,14 +2319,14 @@ func (b *Bot) EditMessageReplyMarkup(ctx context.Context, request *EditMessageRe
 // Does the same as Bot.EditMessageReplyMarkup, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditMessageReplyMarkupVoid(ctx context.Context, request *EditMessageReplyMarkupRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editMessageReplyMarkup", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editMessageReplyMarkup", request)
 }
 
 // Use this method to stop a poll which was sent by the bot. On success, the stopped Poll is returned.
 //
 // https://core.telegram.org/bots/api#stoppoll
 func (b *Bot) StopPoll(ctx context.Context, request *StopPollRequest) (r *Poll, err error) {
-	res, err := makeRequest[*Poll](ctx, b.Options.Client, b.baseURL, "stopPoll", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Poll](ctx, b, "stopPoll", request)
 
 	if err != nil {
 		return r, err
@@ -2338,14 +2338,14 @@ func (b *Bot) StopPoll(ctx context.Context, request *StopPollRequest) (r *Poll,
 // Does the same as Bot.StopPoll, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) StopPollVoid(ctx context.Context, request *StopPollRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "stopPoll", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "stopPoll", request)
 }
 
 // Use this method to approve a suggested post in a direct messages chat. The bot must have the 'can_post_messages' administrator right in the corresponding channel chat. Returns True on success.
 //
 // https://core.telegram.org/bots/api#approvesuggestedpost
 func (b *Bot) ApproveSuggestedPost(ctx context.Context, request *ApproveSuggestedPostRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "approveSuggestedPost", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "approveSuggestedPost", request)
 
 	if err != nil {
 		return r, err
@@ -2357,14 +2357,14 @@ func (b *Bot) ApproveSuggestedPost(ctx context.Context, request *ApproveSuggeste
 // Does the same as Bot.ApproveSuggestedPost, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) ApproveSuggestedPostVoid(ctx context.Context, request *ApproveSuggestedPostRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "approveSuggestedPost", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "approveSuggestedPost", request)
 }
 
 // Use this method to decline a suggested post in a direct messages chat. The bot must have the 'can_manage_direct_messages' administrator right in the corresponding channel chat. Returns True on success.
 //
 // https://core.telegram.org/bots/api#declinesuggestedpost
 func (b *Bot) DeclineSuggestedPost(ctx context.Context, request *DeclineSuggestedPostRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "declineSuggestedPost", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "declineSuggestedPost", request)
 
 	if err != nil {
 		return r, err
@@ -2376,7 +2376,7 @@ func (b *Bot) DeclineSuggestedPost(ctx context.Context, request *DeclineSuggeste
 // Does the same as Bot.DeclineSuggestedPost, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeclineSuggestedPostVoid(ctx context.Context, request *DeclineSuggestedPostRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "declineSuggestedPost", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "declineSuggestedPost", request)
 }
 
 // Use this method to delete a message, including service messages, with the following limitations:
@@ -2403,7 +2403,7 @@ func (b *Bot) DeclineSuggestedPostVoid(ctx context.Context, request *DeclineSugg
 //
 // https://core.telegram.org/bots/api#deletemessage
 func (b *Bot) DeleteMessage(ctx context.Context, request *DeleteMessageRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "deleteMessage", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "deleteMessage", request)
 
 	if err != nil {
 		return r, err
@@ -2415,14 +2415,14 @@ func (b *Bot) DeleteMessage(ctx context.Context, request *DeleteMessageRequest)
 // Does the same as Bot.DeleteMessage, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeleteMessageVoid(ctx context.Context, request *DeleteMessageRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "deleteMessage", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "deleteMessage", request)
 }
 
 // Use this method to delete multiple messages simultaneously. If some of the specified messages can't be found, they are skipped. Returns True on success.
 //
 // https://core.telegram.org/bots/api#deletemessages
 func (b *Bot) DeleteMessages(ctx context.Context, request *DeleteMessagesRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "deleteMessages", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "deleteMessages", request)
 
 	if err != nil {
 		return r, err
@@ -2434,14 +2434,14 @@ func (b *Bot) DeleteMessages(ctx context.Context, request *DeleteMessagesRequest
 // Does the same as Bot.DeleteMessages, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeleteMessagesVoid(ctx context.Context, request *DeleteMessagesRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "deleteMessages", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "deleteMessages", request)
 }
 
 // Use this method to send static .WEBP, animated .TGS, or video .WEBM stickers. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#sendsticker
 func (b *Bot) SendSticker(ctx context.Context, request *SendStickerRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendSticker", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendSticker", request)
 
 	if err != nil {
 		return r, err
@@ -2453,14 +2453,14 @@ func (b *Bot) SendSticker(ctx context.Context, request *SendStickerRequest) (r *
 // Does the same as Bot.SendSticker, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendStickerVoid(ctx context.Context, request *SendStickerRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendSticker", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendSticker", request)
 }
 
 // Use this method to get a sticker set. On success, a StickerSet object is returned.
 //
 // https://core.telegram.org/bots/api#getstickerset
 func (b *Bot) GetStickerSet(ctx context.Context, request *GetStickerSetRequest) (r *StickerSet, err error) {
-	res, err := makeRequest[*StickerSet](ctx, b.Options.Client, b.baseURL, "getStickerSet", b.Options.FloodHandler, request)
+	res, err := makeRequest[*StickerSet](ctx, b, "getStickerSet", request)
 
 	if err != nil {
 		return r, err
@@ -2473,7 +2473,7 @@ func (b *Bot) GetStickerSet(ctx context.Context, request *GetStickerSetRequest)
 //
 // https://core.telegram.org/bots/api#getcustomemojistickers
 func (b *Bot) GetCustomEmojiStickers(ctx context.Context, request *GetCustomEmojiStickersRequest) (r []Sticker, err error) {
-	res, err := makeRequest[[]Sticker](ctx, b.Options.Client, b.baseURL, "getCustomEmojiStickers", b.Options.FloodHandler, request)
+	res, err := makeRequest[[]Sticker](ctx, b, "getCustomEmojiStickers", request)
 
 	if err != nil {
 		return r, err
@@ -2486,7 +2486,7 @@ func (b *Bot) GetCustomEmojiStickers(ctx context.Context, request *GetCustomEmoj
 //
 // https://core.telegram.org/bots/api#uploadstickerfile
 func (b *Bot) UploadStickerFile(ctx context.Context, request *UploadStickerFileRequest) (r *File, err error) {
-	res, err := makeRequest[*File](ctx, b.Options.Client, b.baseURL, "uploadStickerFile", b.Options.FloodHandler, request)
+	res, err := makeRequest[*File](ctx, b, "uploadStickerFile", request)
 
 	if err != nil {
 		return r, err
@@ -2498,14 +2498,14 @@ func (b *Bot) UploadStickerFile(ctx context.Context, request *UploadStickerFileR
 // Does the same as Bot.UploadStickerFile, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) UploadStickerFileVoid(ctx context.Context, request *UploadStickerFileRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "uploadStickerFile", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "uploadStickerFile", request)
 }
 
 // Use this method to create a new sticker set owned by a user. The bot will be able to edit the sticker set thus created. Returns True on success.
 //
 // https://core.telegram.org/bots/api#createnewstickerset
 func (b *Bot) CreateNewStickerSet(ctx context.Context, request *CreateNewStickerSetRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "createNewStickerSet", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "createNewStickerSet", request)
 
 	if err != nil {
 		return r, err
@@ -2517,14 +2517,14 @@ func (b *Bot) CreateNewStickerSet(ctx context.Context, request *CreateNewSticker
 // Does the same as Bot.CreateNewStickerSet, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) CreateNewStickerSetVoid(ctx context.Context, request *CreateNewStickerSetRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "createNewStickerSet", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "createNewStickerSet", request)
 }
 
 // Use this method to add a new sticker to a set created by the bot. Emoji sticker sets can have up to 200 stickers. Other sticker sets can have up to 120 stickers. Returns True on success.
 //
 // https://core.telegram.org/bots/api#addstickertoset
 func (b *Bot) AddStickerToSet(ctx context.Context, request *AddStickerToSetRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "addStickerToSet", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "addStickerToSet", request)
 
 	if err != nil {
 		return r, err
@@ -2536,14 +2536,14 @@ func (b *Bot) AddStickerToSet(ctx context.Context, request *AddStickerToSetReque
 // Does the same as Bot.AddStickerToSet, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) AddStickerToSetVoid(ctx context.Context, request *AddStickerToSetRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "addStickerToSet", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "addStickerToSet", request)
 }
 
 // Use this method to move a sticker in a set created by the bot to a specific position. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setstickerpositioninset
 func (b *Bot) SetStickerPositionInSet(ctx context.Context, request *SetStickerPositionInSetRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setStickerPositionInSet", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setStickerPositionInSet", request)
 
 	if err != nil {
 		return r, err
@@ -2555,14 +2555,14 @@ func (b *Bot) SetStickerPositionInSet(ctx context.Context, request *SetStickerPo
 // Does the same as Bot.SetStickerPositionInSet, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetStickerPositionInSetVoid(ctx context.Context, request *SetStickerPositionInSetRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setStickerPositionInSet", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setStickerPositionInSet", request)
 }
 
 // Use this method to delete a sticker from a set created by the bot. Returns True on success.
 //
 // https://core.telegram.org/bots/api#deletestickerfromset
 func (b *Bot) DeleteStickerFromSet(ctx context.Context, request *DeleteStickerFromSetRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "deleteStickerFromSet", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "deleteStickerFromSet", request)
 
 	if err != nil {
 		return r, err
@@ -2574,14 +2574,14 @@ func (b *Bot) DeleteStickerFromSet(ctx context.Context, request *DeleteStickerFr
 // Does the same as Bot.DeleteStickerFromSet, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeleteStickerFromSetVoid(ctx context.Context, request *DeleteStickerFromSetRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "deleteStickerFromSet", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "deleteStickerFromSet", request)
 }
 
 // Use this method to replace an existing sticker in a sticker set with a new one. The method is equivalent to calling deleteStickerFromSet, then addStickerToSet, then setStickerPositionInSet. Returns True on success.
 //
 // https://core.telegram.org/bots/api#replacestickerinset
 func (b *Bot) ReplaceStickerInSet(ctx context.Context, request *ReplaceStickerInSetRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "replaceStickerInSet", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "replaceStickerInSet", request)
 
 	if err != nil {
 		return r, err
@@ -2593,14 +2593,14 @@ func (b *Bot) ReplaceStickerInSet(ctx context.Context, request *ReplaceStickerIn
 // Does the same as Bot.ReplaceStickerInSet, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) ReplaceStickerInSetVoid(ctx context.Context, request *ReplaceStickerInSetRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "replaceStickerInSet", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "replaceStickerInSet", request)
 }
 
 // Use this method to change the list of emoji assigned to a regular or custom emoji sticker. The sticker must belong to a sticker set created by the bot. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setstickeremojilist
 func (b *Bot) SetStickerEmojiList(ctx context.Context, request *SetStickerEmojiListRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setStickerEmojiList", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setStickerEmojiList", request)
 
 	if err != nil {
 		return r, err
@@ -2612,14 +2612,14 @@ func (b *Bot) SetStickerEmojiList(ctx context.Context, request *SetStickerEmojiL
 // Does the same as Bot.SetStickerEmojiList, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetStickerEmojiListVoid(ctx context.Context, request *SetStickerEmojiListRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setStickerEmojiList", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setStickerEmojiList", request)
 }
 
 // Use this method to change search keywords assigned to a regular or custom emoji sticker. The sticker must belong to a sticker set created by the bot. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setstickerkeywords
 func (b *Bot) SetStickerKeywords(ctx context.Context, request *SetStickerKeywordsRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setStickerKeywords", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setStickerKeywords", request)
 
 	if err != nil {
 		return r, err
@@ -2631,14 +2631,14 @@ func (b *Bot) SetStickerKeywords(ctx context.Context, request *SetStickerKeyword
 // Does the same as Bot.SetStickerKeywords, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetStickerKeywordsVoid(ctx context.Context, request *SetStickerKeywordsRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setStickerKeywords", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setStickerKeywords", request)
 }
 
 // Use this method to change the mask position of a mask sticker. The sticker must belong to a sticker set that was created by the bot. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setstickermaskposition
 func (b *Bot) SetStickerMaskPosition(ctx context.Context, request *SetStickerMaskPositionRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setStickerMaskPosition", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setStickerMaskPosition", request)
 
 	if err != nil {
 		return r, err
@@ -2650,14 +2650,14 @@ func (b *Bot) SetStickerMaskPosition(ctx context.Context, request *SetStickerMas
 // Does the same as Bot.SetStickerMaskPosition, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetStickerMaskPositionVoid(ctx context.Context, request *SetStickerMaskPositionRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setStickerMaskPosition", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setStickerMaskPosition", request)
 }
 
 // Use this method to set the title of a created sticker set. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setstickersettitle
 func (b *Bot) SetStickerSetTitle(ctx context.Context, request *SetStickerSetTitleRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setStickerSetTitle", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setStickerSetTitle", request)
 
 	if err != nil {
 		return r, err
@@ -2669,14 +2669,14 @@ func (b *Bot) SetStickerSetTitle(ctx context.Context, request *SetStickerSetTitl
 // Does the same as Bot.SetStickerSetTitle, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetStickerSetTitleVoid(ctx context.Context, request *SetStickerSetTitleRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setStickerSetTitle", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setStickerSetTitle", request)
 }
 
 // Use this method to set the thumbnail of a regular or mask sticker set. The format of the thumbnail file must match the format of the stickers in the set. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setstickersetthumbnail
 func (b *Bot) SetStickerSetThumbnail(ctx context.Context, request *SetStickerSetThumbnailRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setStickerSetThumbnail", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setStickerSetThumbnail", request)
 
 	if err != nil {
 		return r, err
@@ -2688,14 +2688,14 @@ func (b *Bot) SetStickerSetThumbnail(ctx context.Context, request *SetStickerSet
 // Does the same as Bot.SetStickerSetThumbnail, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetStickerSetThumbnailVoid(ctx context.Context, request *SetStickerSetThumbnailRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setStickerSetThumbnail", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setStickerSetThumbnail", request)
 }
 
 // Use this method to set the thumbnail of a custom emoji sticker set. Returns True on success.
 //
 // https://core.telegram.org/bots/api#setcustomemojistickersetthumbnail
 func (b *Bot) SetCustomEmojiStickerSetThumbnail(ctx context.Context, request *SetCustomEmojiStickerSetThumbnailRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setCustomEmojiStickerSetThumbnail", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setCustomEmojiStickerSetThumbnail", request)
 
 	if err != nil {
 		return r, err
@@ -2707,14 +2707,14 @@ func (b *Bot) SetCustomEmojiStickerSetThumbnail(ctx context.Context, request *Se
 // Does the same as Bot.SetCustomEmojiStickerSetThumbnail, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetCustomEmojiStickerSetThumbnailVoid(ctx context.Context, request *SetCustomEmojiStickerSetThumbnailRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setCustomEmojiStickerSetThumbnail", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setCustomEmojiStickerSetThumbnail", request)
 }
 
 // Use this method to delete a sticker set that was created by the bot. Returns True on success.
 //
 // https://core.telegram.org/bots/api#deletestickerset
 func (b *Bot) DeleteStickerSet(ctx context.Context, request *DeleteStickerSetRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "deleteStickerSet", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "deleteStickerSet", request)
 
 	if err != nil {
 		return r, err
@@ -2726,7 +2726,7 @@ func (b *Bot) DeleteStickerSet(ctx context.Context, request *DeleteStickerSetReq
 // Does the same as Bot.DeleteStickerSet, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) DeleteStickerSetVoid(ctx context.Context, request *DeleteStickerSetRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "deleteStickerSet", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "deleteStickerSet", request)
 }
 
 // Use this method to send answers to an inline query. On success, True is returned.
@@ -2735,7 +2735,7 @@ func (b *Bot) DeleteStickerSetVoid(ctx context.Context, request *DeleteStickerSe
 //
 // https://core.telegram.org/bots/api#answerinlinequery
 func (b *Bot) AnswerInlineQuery(ctx context.Context, request *AnswerInlineQueryRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "answerInlineQuery", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "answerInlineQuery", request)
 
 	if err != nil {
 		return r, err
@@ -2747,14 +2747,14 @@ func (b *Bot) AnswerInlineQuery(ctx context.Context, request *AnswerInlineQueryR
 // Does the same as Bot.AnswerInlineQuery, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) AnswerInlineQueryVoid(ctx context.Context, request *AnswerInlineQueryRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "answerInlineQuery", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "answerInlineQuery", request)
 }
 
 // Use this method to set the result of an interaction with a Web App and send a corresponding message on behalf of the user to the chat from which the query originated. On success, a SentWebAppMessage object is returned.
 //
 // https://core.telegram.org/bots/api#answerwebappquery
 func (b *Bot) AnswerWebAppQuery(ctx context.Context, request *AnswerWebAppQueryRequest) (r *SentWebAppMessage, err error) {
-	res, err := makeRequest[*SentWebAppMessage](ctx, b.Options.Client, b.baseURL, "answerWebAppQuery", b.Options.FloodHandler, request)
+	res, err := makeRequest[*SentWebAppMessage](ctx, b, "answerWebAppQuery", request)
 
 	if err != nil {
 		return r, err
@@ -2766,14 +2766,14 @@ func (b *Bot) AnswerWebAppQuery(ctx context.Context, request *AnswerWebAppQueryR
 // Does the same as Bot.AnswerWebAppQuery, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) AnswerWebAppQueryVoid(ctx context.Context, request *AnswerWebAppQueryRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "answerWebAppQuery", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "answerWebAppQuery", request)
 }
 
 // Stores a message that can be sent by a user of a Mini App. Returns a PreparedInlineMessage object.
 //
 // https://core.telegram.org/bots/api#savepreparedinlinemessage
 func (b *Bot) SavePreparedInlineMessage(ctx context.Context, request *SavePreparedInlineMessageRequest) (r *PreparedInlineMessage, err error) {
-	res, err := makeRequest[*PreparedInlineMessage](ctx, b.Options.Client, b.baseURL, "savePreparedInlineMessage", b.Options.FloodHandler, request)
+	res, err := makeRequest[*PreparedInlineMessage](ctx, b, "savePreparedInlineMessage", request)
 
 	if err != nil {
 		return r, err
@@ -2785,14 +2785,14 @@ func (b *Bot) SavePreparedInlineMessage(ctx context.Context, request *SavePrepar
 // Does the same as Bot.SavePreparedInlineMessage, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SavePreparedInlineMessageVoid(ctx context.Context, request *SavePreparedInlineMessageRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "savePreparedInlineMessage", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "savePreparedInlineMessage", request)
 }
 
 // Use this method to send invoices. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#sendinvoice
 func (b *Bot) SendInvoice(ctx context.Context, request *SendInvoiceRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendInvoice", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendInvoice", request)
 
 	if err != nil {
 		return r, err
@@ -2804,14 +2804,14 @@ func (b *Bot) SendInvoice(ctx context.Context, request *SendInvoiceRequest) (r *
 // Does the same as Bot.SendInvoice, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendInvoiceVoid(ctx context.Context, request *SendInvoiceRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendInvoice", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendInvoice", request)
 }
 
 // Use this method to create a link for an invoice. Returns the created invoice link as String on success.
 //
 // https://core.telegram.org/bots/api#createinvoicelink
 func (b *Bot) CreateInvoiceLink(ctx context.Context, request *CreateInvoiceLinkRequest) (r string, err error) {
-	res, err := makeRequest[string](ctx, b.Options.Client, b.baseURL, "createInvoiceLink", b.Options.FloodHandler, request)
+	res, err := makeRequest[string](ctx, b, "createInvoiceLink", request)
 
 	if err != nil {
 		return r, err
@@ -2823,14 +2823,14 @@ func (b *Bot) CreateInvoiceLink(ctx context.Context, request *CreateInvoiceLinkR
 // Does the same as Bot.CreateInvoiceLink, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) CreateInvoiceLinkVoid(ctx context.Context, request *CreateInvoiceLinkRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "createInvoiceLink", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "createInvoiceLink", request)
 }
 
 // If you sent an invoice requesting a shipping address and the parameter is_flexible was specified, the Bot API will send an Update with a shipping_query field to the bot. Use this method to reply to shipping queries. On success, True is returned.
 //
 // https://core.telegram.org/bots/api#answershippingquery
 func (b *Bot) AnswerShippingQuery(ctx context.Context, request *AnswerShippingQueryRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "answerShippingQuery", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "answerShippingQuery", request)
 
 	if err != nil {
 		return r, err
@@ -2842,14 +2842,14 @@ func (b *Bot) AnswerShippingQuery(ctx context.Context, request *AnswerShippingQu
 // Does the same as Bot.AnswerShippingQuery, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) AnswerShippingQueryVoid(ctx context.Context, request *AnswerShippingQueryRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "answerShippingQuery", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "answerShippingQuery", request)
 }
 
 // Once the user has confirmed their payment and shipping details, the Bot API sends the final confirmation in the form of an Update with the field pre_checkout_query. Use this method to respond to such pre-checkout queries. On success, True is returned. Note: The Bot API must receive an answer within 10 seconds after the pre-checkout query was sent.
 //
 // https://core.telegram.org/bots/api#answerprecheckoutquery
 func (b *Bot) AnswerPreCheckoutQuery(ctx context.Context, request *AnswerPreCheckoutQueryRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "answerPreCheckoutQuery", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "answerPreCheckoutQuery", request)
 
 	if err != nil {
 		return r, err
@@ -2861,14 +2861,14 @@ func (b *Bot) AnswerPreCheckoutQuery(ctx context.Context, request *AnswerPreChec
 // Does the same as Bot.AnswerPreCheckoutQuery, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) AnswerPreCheckoutQueryVoid(ctx context.Context, request *AnswerPreCheckoutQueryRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "answerPreCheckoutQuery", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "answerPreCheckoutQuery", request)
 }
 
 // A method to get the current Telegram Stars balance of the bot. Requires no parameters. On success, returns a StarAmount object.
 //
 // https://core.telegram.org/bots/api#getmystarbalance
 func (b *Bot) GetMyStarBalance(ctx context.Context) (r *StarAmount, err error) {
-	res, err := makeRequest[*StarAmount](ctx, b.Options.Client, b.baseURL, "getMyStarBalance", b.Options.FloodHandler, nil)
+	res, err := makeRequest[*StarAmount](ctx, b, "getMyStarBalance", nil)
 
 	if err != nil {
 		return r, err
@@ -2881,7 +2881,7 @@ func (b *Bot) GetMyStarBalance(ctx context.Context) (r *StarAmount, err error) {
 //
 // https://core.telegram.org/bots/api#getstartransactions
 func (b *Bot) GetStarTransactions(ctx context.Context, request *GetStarTransactionsRequest) (r *StarTransactions, err error) {
-	res, err := makeRequest[*StarTransactions](ctx, b.Options.Client, b.baseURL, "getStarTransactions", b.Options.FloodHandler, request)
+	res, err := makeRequest[*StarTransactions](ctx, b, "getStarTransactions", request)
 
 	if err != nil {
 		return r, err
@@ -2894,7 +2894,7 @@ func (b *Bot) GetStarTransactions(ctx context.Context, request *GetStarTransacti
 //
 // https://core.telegram.org/bots/api#refundstarpayment
 func (b *Bot) RefundStarPayment(ctx context.Context, request *RefundStarPaymentRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "refundStarPayment", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "refundStarPayment", request)
 
 	if err != nil {
 		return r, err
@@ -2906,14 +2906,14 @@ func (b *Bot) RefundStarPayment(ctx context.Context, request *RefundStarPaymentR
 // Does the same as Bot.RefundStarPayment, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) RefundStarPaymentVoid(ctx context.Context, request *RefundStarPaymentRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "refundStarPayment", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "refundStarPayment", request)
 }
 
 // Allows the bot to cancel or re-enable extension of a subscription paid in Telegram Stars. Returns True on success.
 //
 // https://core.telegram.org/bots/api#edituserstarsubscription
 func (b *Bot) EditUserStarSubscription(ctx context.Context, request *EditUserStarSubscriptionRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "editUserStarSubscription", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "editUserStarSubscription", request)
 
 	if err != nil {
 		return r, err
@@ -2925,7 +2925,7 @@ func (b *Bot) EditUserStarSubscription(ctx context.Context, request *EditUserSta
 // Does the same as Bot.EditUserStarSubscription, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) EditUserStarSubscriptionVoid(ctx context.Context, request *EditUserStarSubscriptionRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "editUserStarSubscription", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "editUserStarSubscription", request)
 }
 
 // Informs a user that some of the Telegram Passport elements they provided contains errors. The user will not be able to re-submit their Passport to you until the errors are fixed (the contents of the field for which you returned the error must change). Returns True on success.
@@ -2934,7 +2934,7 @@ func (b *Bot) EditUserStarSubscriptionVoid(ctx context.Context, request *EditUse
 //
 // https://core.telegram.org/bots/api#setpassportdataerrors
 func (b *Bot) SetPassportDataErrors(ctx context.Context, request *SetPassportDataErrorsRequest) (r bool, err error) {
-	res, err := makeRequest[bool](ctx, b.Options.Client, b.baseURL, "setPassportDataErrors", b.Options.FloodHandler, request)
+	res, err := makeRequest[bool](ctx, b, "setPassportDataErrors", request)
 
 	if err != nil {
 		return r, err
@@ -2946,14 +2946,14 @@ func (b *Bot) SetPassportDataErrors(ctx context.Context, request *SetPassportDat
 // Does the same as Bot.SetPassportDataErrors, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetPassportDataErrorsVoid(ctx context.Context, request *SetPassportDataErrorsRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setPassportDataErrors", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setPassportDataErrors", request)
 }
 
 // Use this method to send a game. On success, the sent Message is returned.
 //
 // https://core.telegram.org/bots/api#sendgame
 func (b *Bot) SendGame(ctx context.Context, request *SendGameRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "sendGame", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "sendGame", request)
 
 	if err != nil {
 		return r, err
@@ -2965,14 +2965,14 @@ func (b *Bot) SendGame(ctx context.Context, request *SendGameRequest) (r *Messag
 // Does the same as Bot.SendGame, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SendGameVoid(ctx context.Context, request *SendGameRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "sendGame", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "sendGame", request)
 }
 
 // Use this method to set the score of the specified user in a game message. On success, if the message is not an inline message, the Message is returned, otherwise True is returned. Returns an error, if the new score is not greater than the user's current score in the chat and force is False.
 //
 // https://core.telegram.org/bots/api#setgamescore
 func (b *Bot) SetGameScore(ctx context.Context, request *SetGameScoreRequest) (r *Message, err error) {
-	res, err := makeRequest[*Message](ctx, b.Options.Client, b.baseURL, "setGameScore", b.Options.FloodHandler, request)
+	res, err := makeRequest[*Message](ctx, b, "setGameScore", request)
 
 	if err != nil {
 		return r, err
@@ -2984,14 +2984,14 @@ func (b *Bot) SetGameScore(ctx context.Context, request *SetGameScoreRequest) (r
 // Does the same as Bot.SetGameScore, but parses response body only in case of an error.
 // Therefore works faster if you dont need the response value.
 func (b *Bot) SetGameScoreVoid(ctx context.Context, request *SetGameScoreRequest) error {
-	return makeVoidRequest(ctx, b.Options.Client, b.baseURL, "setGameScore", b.Options.FloodHandler, request)
+	return makeVoidRequest(ctx, b, "setGameScore", request)
 }
 
 // Use this method to get data for high score tables. Will return the score of the specified user and several of their neighbors in a game. Returns an Array of GameHighScore objects.
 //
 // https://core.telegram.org/bots/api#getgamehighscores
 func (b *Bot) GetGameHighScores(ctx context.Context, request *GetGameHighScoresRequest) (r []GameHighScore, err error) {
-	res, err := makeRequest[[]GameHighScore](ctx, b.Options.Client, b.baseURL, "getGameHighScores", b.Options.FloodHandler, request)
+	res, err := makeRequest[[]GameHighScore](ctx, b, "getGameHighScores", request)
 
 	if err != nil {
 		return r, err
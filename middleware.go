@@ -0,0 +1,51 @@
+package goram
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/TrixiS/goram/flood"
+)
+
+// Calls the next middleware or sends the request to Telegram.
+//
+// request is a typed request value (for example, *goram.SendMessageRequest) or nil for methods without parameters.
+// Returns raw "result" field of the API response. The result is nil for Void methods (for example, Bot.SendMessageVoid()).
+type RequestFunc func(ctx context.Context, method string, request any) (json.RawMessage, error)
+
+// Request interceptor. See BotOptions.Middlewares.
+//
+// A middleware can inspect or replace the request, skip calling next (for example, to stub a method in tests)
+// or inspect the result and error returned by next. A replaced request must be of the same type as the original one.
+type Middleware func(ctx context.Context, method string, request any, next RequestFunc) (json.RawMessage, error)
+
+// Max amount of attempts for a request that fails with 429 error
+const maxFloodAttempts = 5
+
+// Calls handler.Enter() before every attempt and handler.Handle() on 429 error, then retries the request.
+// Gives up after 5 attempts.
+//
+// BotOptions.FloodHandler is the same as adding this middleware as the last one in BotOptions.Middlewares.
+func FloodMiddleware(handler flood.Handler) Middleware {
+	return func(ctx context.Context, method string, request any, next RequestFunc) (json.RawMessage, error) {
+		for attempt := 1; ; attempt++ {
+			handler.Enter(ctx, method, request)
+			result, err := next(ctx, method, request)
+
+			var floodError *FloodError
+
+			if attempt >= maxFloodAttempts || !errors.As(err, &floodError) {
+				return result, err
+			}
+
+			handler.Handle(ctx, method, request, floodError.RetryAfter)
+		}
+	}
+}
+
+func wrapMiddleware(middleware Middleware, next RequestFunc) RequestFunc {
+	return func(ctx context.Context, method string, request any) (json.RawMessage, error) {
+		return middleware(ctx, method, request, next)
+	}
+}
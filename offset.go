@@ -0,0 +1,71 @@
+package goram
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/TrixiS/goram/internal/fileutil"
+)
+
+// Persists long polling offset (id of the next update to request) between restarts. See PollerOptions.OffsetStore.
+type OffsetStore interface {
+	LoadOffset(ctx context.Context) (int64, error) // Returns 0 if there is no saved offset
+	SaveOffset(ctx context.Context, offset int64) error
+}
+
+// In-memory offset store. Offset survives poller restarts, but not process restarts.
+type MemoryOffsetStore struct {
+	mu     sync.Mutex
+	offset int64
+}
+
+func (m *MemoryOffsetStore) LoadOffset(ctx context.Context) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.offset, nil
+}
+
+func (m *MemoryOffsetStore) SaveOffset(ctx context.Context, offset int64) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.offset = offset
+	return nil
+}
+
+// Offset store that keeps the offset in a text file. The file is replaced atomically on every save.
+type FileOffsetStore struct {
+	Path string
+	mu   sync.Mutex
+}
+
+func NewFileOffsetStore(path string) *FileOffsetStore {
+	return &FileOffsetStore{Path: path}
+}
+
+func (f *FileOffsetStore) LoadOffset(ctx context.Context) (int64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	content, err := os.ReadFile(f.Path)
+
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return 0, nil
+		}
+
+		return 0, err
+	}
+
+	return strconv.ParseInt(strings.TrimSpace(string(content)), 10, 64)
+}
+
+func (f *FileOffsetStore) SaveOffset(ctx context.Context, offset int64) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return fileutil.WriteFileAtomic(f.Path, []byte(strconv.FormatInt(offset, 10)))
+}
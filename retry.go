@@ -0,0 +1,198 @@
+package goram
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math/rand"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	DefaultRetryMaxAttempts = 3
+	DefaultRetryBackoff     = time.Millisecond * 500
+	DefaultRetryMaxBackoff  = time.Second * 10
+)
+
+// Max amount of response body bytes kept in goram.HTTPError
+const maxHTTPErrorBodySize = 512
+
+// Retry policy for transient network and 5xx errors. See BotOptions.RetryPolicy.
+//
+// 429 flood errors are not affected by the policy, see BotOptions.FloodHandler.
+type RetryPolicy struct {
+	MaxAttempts int                                         // Optional. Total amount of attempts, including the first one. Default is goram.DefaultRetryMaxAttempts
+	Backoff     time.Duration                               // Optional. Sleep duration before the first retry, doubled on every next retry. Default is goram.DefaultRetryBackoff
+	MaxBackoff  time.Duration                               // Optional. Default is goram.DefaultRetryMaxBackoff
+	Retryable   func(method string, err error) bool         // Optional. Default is goram.IsRetryable
+	Idempotent  func(method string) bool                    // Optional. Default is goram.IsIdempotent
+	OnRetry     func(method string, attempt int, err error) // Optional. Gets called before sleeping
+}
+
+// Returns true if the error is a transient network error or a 5xx response.
+//
+// Non-idempotent methods (see goram.IsIdempotent) are retried only if the request surely did not reach the server,
+// because otherwise a message could be sent twice.
+func IsRetryable(method string, err error) bool {
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	if isDialError(err) {
+		return true
+	}
+
+	var httpError *HTTPError
+
+	if errors.As(err, &httpError) {
+		return httpError.StatusCode >= http.StatusInternalServerError
+	}
+
+	var apiError *APIError
+
+	if errors.As(err, &apiError) && apiError.ErrorCode >= http.StatusInternalServerError {
+		return true
+	}
+
+	var netError net.Error
+
+	return (errors.As(err, &netError) && netError.Timeout()) ||
+		errors.Is(err, syscall.ECONNRESET) ||
+		errors.Is(err, io.ErrUnexpectedEOF) ||
+		errors.Is(err, io.EOF)
+}
+
+var nonIdempotentPrefixes = []string{
+	"send",
+	"forward",
+	"copy",
+	"create",
+	"add",
+	"post",
+	"repost",
+	"gift",
+	"transfer",
+	"upgrade",
+	"convert",
+}
+
+// Returns false for methods that create something on every call, like sendMessage or createChatInviteLink.
+func IsIdempotent(method string) bool {
+	for _, prefix := range nonIdempotentPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isDialError(err error) bool {
+	var opError *net.OpError
+
+	if errors.As(err, &opError) && opError.Op == "dial" {
+		return true
+	}
+
+	var dnsError *net.DNSError
+	return errors.As(err, &dnsError) || errors.Is(err, syscall.ECONNREFUSED)
+}
+
+func (p *RetryPolicy) shouldRetry(method string, attempt int, err error) bool {
+	maxAttempts := p.MaxAttempts
+
+	if maxAttempts <= 0 {
+		maxAttempts = DefaultRetryMaxAttempts
+	}
+
+	if attempt+1 >= maxAttempts {
+		return false
+	}
+
+	idempotent := IsIdempotent
+
+	if p.Idempotent != nil {
+		idempotent = p.Idempotent
+	}
+
+	if !idempotent(method) && !isDialError(err) {
+		return false
+	}
+
+	if p.Retryable != nil {
+		return p.Retryable(method, err)
+	}
+
+	return IsRetryable(method, err)
+}
+
+// Sleeps before the next attempt. Returns false if ctx is done.
+func (p *RetryPolicy) wait(ctx context.Context, method string, attempt int, err error) bool {
+	base, maxBackoff := p.Backoff, p.MaxBackoff
+
+	if base <= 0 {
+		base = DefaultRetryBackoff
+	}
+
+	if maxBackoff <= 0 {
+		maxBackoff = DefaultRetryMaxBackoff
+	}
+
+	d := backoff(base, maxBackoff, attempt)
+
+	if p.OnRetry != nil {
+		p.OnRetry(method, attempt+1, err)
+	}
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(d):
+		return true
+	}
+}
+
+// Returns base doubled attempt times, capped by maxBackoff, with jitter in [d/2, d].
+// Used by RetryPolicy and Poller conflict backoff.
+func backoff(base time.Duration, maxBackoff time.Duration, attempt int) time.Duration {
+	d := base
+
+	for i := 0; i < attempt && d < maxBackoff; i++ {
+		d *= 2
+	}
+
+	if d > maxBackoff {
+		d = maxBackoff
+	}
+
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// Telegram (or a reverse proxy) responded with something that is not a JSON API response,
+// like an HTML 502 page.
+type HTTPError struct {
+	StatusCode int
+	Status     string
+	Body       string // Truncated response body
+}
+
+func (h *HTTPError) Error() string {
+	return "unexpected response " + h.Status + ": " + h.Body
+}
+
+func newHTTPError(res *http.Response, body []byte) *HTTPError {
+	if len(body) > maxHTTPErrorBodySize {
+		body = body[:maxHTTPErrorBodySize]
+	}
+
+	return &HTTPError{
+		StatusCode: res.StatusCode,
+		Status:     res.Status,
+		Body:       string(body),
+	}
+}
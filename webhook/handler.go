@@ -0,0 +1,125 @@
+package webhook
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/TrixiS/goram"
+	"github.com/TrixiS/goram/handlers"
+)
+
+// Default max size of a webhook request body (1 MiB)
+const DefaultMaxBodySize = 1 << 20
+
+// Gets called for every update received by a webhook.
+type UpdateFunc func(ctx context.Context, update *goram.Update) error
+
+// Gets called when an error happens. Update is nil if the error is not related to a specific update.
+type ErrorFunc func(ctx context.Context, update *goram.Update, err error)
+
+type HandlerOptions struct {
+	OnUpdate    UpdateFunc // Required
+	OnError     ErrorFunc  // Optional. Gets called if OnUpdate returns an error
+	SecretToken string     // Optional. If set, requests without the same goram.WebhookSecretHeaderKey header value are rejected with 403
+	MaxBodySize int64      // Optional. If MaxBodySize is 0, webhook.DefaultMaxBodySize will be used
+}
+
+// http.Handler that decodes Telegram webhook requests and passes updates to OnUpdate.
+//
+// Secret token is compared in constant time.
+// The handler always responds with 200 once an update is decoded, even if OnUpdate returns an error,
+// so Telegram does not redeliver the same update over and over again.
+type Handler struct {
+	Options HandlerOptions
+	wg      sync.WaitGroup
+}
+
+func NewHandler(options HandlerOptions) *Handler {
+	if options.MaxBodySize <= 0 {
+		options.MaxBodySize = DefaultMaxBodySize
+	}
+
+	return &Handler{Options: options}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if !h.checkSecret(r.Header.Get(goram.WebhookSecretHeaderKey)) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	h.wg.Add(1)
+	defer h.wg.Done()
+
+	body := http.MaxBytesReader(w, r.Body, h.Options.MaxBodySize)
+	update := &goram.Update{}
+
+	if err := json.NewDecoder(body).Decode(update); err != nil {
+		var maxBytesError *http.MaxBytesError
+
+		if errors.As(err, &maxBytesError) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		} else {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+
+		return
+	}
+
+	ctx := r.Context()
+
+	if err := h.Options.OnUpdate(ctx, update); err != nil && h.Options.OnError != nil {
+		h.Options.OnError(ctx, update, err)
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// Blocks until all in-flight updates are handled.
+func (h *Handler) Wait() {
+	h.wg.Wait()
+}
+
+func (h *Handler) checkSecret(secret string) bool {
+	if h.Options.SecretToken == "" {
+		return true
+	}
+
+	return subtle.ConstantTimeCompare([]byte(secret), []byte(h.Options.SecretToken)) == 1
+}
+
+// Creates UpdateFunc that feeds every update to the router with empty handlers.Data.
+func RouterFunc(bot *goram.Bot, router *handlers.Router) UpdateFunc {
+	return func(ctx context.Context, update *goram.Update) error {
+		_, err := router.FeedUpdate(ctx, bot, update, handlers.Data{})
+		return err
+	}
+}
+
+// Creates UpdateFunc that sends every update to the channel as a single element batch.
+// This way a webhook can be used in place of goram.LongPollUpdates.
+//
+// Blocks while the channel is full, so Telegram will wait (and eventually retry) too.
+// Sending is also aborted once ctx is done, since http.Server.Shutdown() does not cancel request contexts
+// and the channel consumer is likely to be stopped by then. Pass the ctx the server runs with.
+func ChanFunc(ctx context.Context, c chan<- []goram.Update) UpdateFunc {
+	return func(requestCtx context.Context, update *goram.Update) error {
+		select {
+		case c <- []goram.Update{*update}:
+			return nil
+		case <-requestCtx.Done():
+			return requestCtx.Err()
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
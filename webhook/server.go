@@ -0,0 +1,171 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/TrixiS/goram"
+	"github.com/TrixiS/goram/handlers"
+)
+
+var ErrNoUpdateHandler = errors.New("webhook: OnUpdate or Router is required")
+
+type ServerOptions struct {
+	Bot                *goram.Bot         // Required
+	URL                string             // Required. Public HTTPS url passed to Bot.SetWebhook(). Its path is used as the handler path
+	ListenAddr         string             // Required. For example ":8080"
+	SecretToken        string             // Optional, but strongly recommended. See goram.SetWebhookRequest.SecretToken
+	Router             *handlers.Router   // Optional. If set, updates are fed to the router (unless OnUpdate is set) and AllowedUpdates are taken from router.GetUsedUpdateTypes()
+	OnUpdate           UpdateFunc         // Optional. Required if Router is nil
+	OnError            ErrorFunc          // Optional. Gets called on update handling errors and on errors that happen after the server has started
+	AllowedUpdates     []goram.UpdateType // Optional. Overrides allowed updates taken from Router
+	MaxConnections     int                // Optional. See goram.SetWebhookRequest.MaxConnections
+	DropPendingUpdates bool               // Optional. Drop pending updates when setting the webhook
+	MaxBodySize        int64              // Optional. See webhook.HandlerOptions.MaxBodySize
+	DeleteWebhook      bool               // Optional. Call Bot.DeleteWebhook() on shutdown
+	ShutdownTimeout    time.Duration      // Optional. Max duration to wait for in-flight updates on shutdown. Default is unlimited
+}
+
+// Webhook server. It registers the webhook, serves updates and shuts down gracefully.
+//
+// See Server.Run() and Server.Updates().
+type Server struct {
+	Options ServerOptions
+}
+
+func NewServer(options ServerOptions) *Server {
+	return &Server{Options: options}
+}
+
+// Starts listening, sets the webhook via Bot.SetWebhook() and serves updates until ctx is done.
+//
+// On shutdown stops accepting new requests, waits for in-flight updates to be handled
+// and calls Bot.DeleteWebhook() if ServerOptions.DeleteWebhook is true.
+func (s *Server) Run(ctx context.Context) error {
+	onUpdate := s.Options.OnUpdate
+
+	if onUpdate == nil {
+		if s.Options.Router == nil {
+			return ErrNoUpdateHandler
+		}
+
+		onUpdate = RouterFunc(s.Options.Bot, s.Options.Router)
+	}
+
+	return s.run(ctx, onUpdate)
+}
+
+// Runs the server in background and streams received updates the same way goram.LongPollUpdates() does.
+// Every webhook request becomes a single element batch.
+//
+// The returned channel gets closed after the server shuts down and the webhook handler is drained.
+// Errors are reported to ServerOptions.OnError. ServerOptions.OnUpdate is ignored.
+func (s *Server) Updates(ctx context.Context, cap uint) chan []goram.Update {
+	c := make(chan []goram.Update, cap)
+
+	go func() {
+		defer close(c)
+
+		if err := s.run(ctx, ChanFunc(ctx, c)); err != nil && s.Options.OnError != nil {
+			s.Options.OnError(ctx, nil, err)
+		}
+	}()
+
+	return c
+}
+
+func (s *Server) run(ctx context.Context, onUpdate UpdateFunc) error {
+	webhookURL, err := url.Parse(s.Options.URL)
+
+	if err != nil {
+		return err
+	}
+
+	path := webhookURL.Path
+
+	if path == "" {
+		path = "/"
+	}
+
+	handler := NewHandler(HandlerOptions{
+		OnUpdate:    onUpdate,
+		OnError:     s.Options.OnError,
+		SecretToken: s.Options.SecretToken,
+		MaxBodySize: s.Options.MaxBodySize,
+	})
+
+	mux := http.NewServeMux()
+	mux.Handle(path, handler)
+
+	listener, err := net.Listen("tcp", s.Options.ListenAddr)
+
+	if err != nil {
+		return err
+	}
+
+	server := &http.Server{Handler: mux}
+	serveErr := make(chan error, 1)
+
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	if err := s.setWebhook(ctx); err != nil {
+		s.shutdown(server, handler)
+		return err
+	}
+
+	select {
+	case err := <-serveErr:
+		handler.Wait()
+		return err
+	case <-ctx.Done():
+	}
+
+	if err := s.shutdown(server, handler); err != nil {
+		return err
+	}
+
+	if s.Options.DeleteWebhook {
+		return s.Options.Bot.DeleteWebhookVoid(context.Background(), &goram.DeleteWebhookRequest{})
+	}
+
+	return nil
+}
+
+func (s *Server) setWebhook(ctx context.Context) error {
+	allowedUpdates := s.Options.AllowedUpdates
+
+	if allowedUpdates == nil && s.Options.Router != nil {
+		allowedUpdates = s.Options.Router.GetUsedUpdateTypes()
+	}
+
+	return s.Options.Bot.SetWebhookVoid(ctx, &goram.SetWebhookRequest{
+		URL:                s.Options.URL,
+		MaxConnections:     s.Options.MaxConnections,
+		AllowedUpdates:     allowedUpdates,
+		DropPendingUpdates: s.Options.DropPendingUpdates,
+		SecretToken:        s.Options.SecretToken,
+	})
+}
+
+func (s *Server) shutdown(server *http.Server, handler *Handler) error {
+	ctx := context.Background()
+
+	if s.Options.ShutdownTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.Options.ShutdownTimeout)
+		defer cancel()
+	}
+
+	if err := server.Shutdown(ctx); err != nil {
+		return err
+	}
+
+	handler.Wait()
+	return nil
+}